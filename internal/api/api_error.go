@@ -0,0 +1,26 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"rating-api/internal/service"
+)
+
+// ErrorStatus
+// Maps an error returned by a service to the HTTP status code of its kind. Unknown errors are internal errors.
+func ErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrValidation):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrDuplicate):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	}
+
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"rating-api/internal/util/validator"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemJsonMime
+// Media type of RFC 7807 problem details. Clients asking for it in the Accept header get errors in this format.
+const ProblemJsonMime = "application/problem+json"
+
+type ProblemDetails struct {
+	Type     string             `json:"type"`
+	Title    string             `json:"title"`
+	Status   int                `json:"status"`
+	Detail   string             `json:"detail,omitempty"`
+	Instance string             `json:"instance,omitempty"`
+	Errors   []ProblemViolation `json:"errors,omitempty"`
+}
+
+type ProblemViolation struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// WriteError
+// Writes err with the given status as problem details when the client accepts application/problem+json,
+// otherwise as an ApiResponse.
+func WriteError(c *gin.Context, status int, err error) {
+	if c.NegotiateFormat(gin.MIMEJSON, ProblemJsonMime) != ProblemJsonMime {
+		c.JSON(status, RespondErrorOf(err))
+		return
+	}
+
+	problem := ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   err.Error(),
+		Instance: c.Request.URL.Path,
+	}
+
+	var validationErr *validator.ValidationError
+	if errors.As(err, &validationErr) {
+		problem.Detail = "The request has invalid fields."
+		for _, violation := range validationErr.Violations {
+			problem.Errors = append(problem.Errors, ProblemViolation(violation))
+		}
+	}
+
+	c.Render(status, problemRender{problem: problem})
+}
+
+// AbortWithError
+// Stops the handler chain and writes err like WriteError.
+func AbortWithError(c *gin.Context, status int, err error) {
+	c.Abort()
+	WriteError(c, status, err)
+}
+
+type problemRender struct {
+	problem ProblemDetails
+}
+
+func (r problemRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+
+	body, err := json.Marshal(r.problem)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(body)
+	return err
+}
+
+func (r problemRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ProblemJsonMime)
+}
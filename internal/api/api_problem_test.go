@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"rating-api/internal/util/validator"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type testRateModel struct {
+	Rate int `json:"Rate" validate:"gte=1,lte=5"`
+}
+
+type ProblemTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+// Run suite.
+func TestProblem(t *testing.T) {
+	suite.Run(t, new(ProblemTestSuite))
+}
+
+// Runs before each test in the suite.
+func (p *ProblemTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	p.router = gin.New()
+	p.router.POST("ratings", func(c *gin.Context) {
+		WriteError(c, http.StatusUnprocessableEntity, validator.New().ValidateStruct(&testRateModel{Rate: 6}))
+	})
+	p.router.GET("ratings/:id", func(c *gin.Context) {
+		AbortWithError(c, http.StatusNotFound, errors.New("rate not found"))
+	})
+}
+
+func (p *ProblemTestSuite) send(method string, target string, accept string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, nil)
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+
+	recorder := httptest.NewRecorder()
+	p.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func (p *ProblemTestSuite) TestWriteError_AcceptProblemJson_WritesProblemDetails() {
+	recorder := p.send(http.MethodPost, "/ratings", ProblemJsonMime)
+
+	p.Equal(http.StatusUnprocessableEntity, recorder.Code)
+	p.Equal(ProblemJsonMime, recorder.Header().Get("Content-Type"))
+
+	var problem ProblemDetails
+	p.Require().Nil(json.Unmarshal(recorder.Body.Bytes(), &problem))
+	p.Equal(ProblemDetails{
+		Type:     "about:blank",
+		Title:    "Unprocessable Entity",
+		Status:   http.StatusUnprocessableEntity,
+		Detail:   "The request has invalid fields.",
+		Instance: "/ratings",
+		Errors: []ProblemViolation{
+			{Field: "Rate", Rule: "lte", Param: "5", Message: "Rate must be at most 5"},
+		},
+	}, problem)
+}
+
+func (p *ProblemTestSuite) TestWriteError_AcceptProblemJson_PlainError_HasNoErrors() {
+	recorder := p.send(http.MethodGet, "/ratings/1", ProblemJsonMime+", application/json;q=0.5")
+
+	p.Equal(http.StatusNotFound, recorder.Code)
+	p.Equal(ProblemJsonMime, recorder.Header().Get("Content-Type"))
+	p.JSONEq(`{"type":"about:blank","title":"Not Found","status":404,"detail":"rate not found","instance":"/ratings/1"}`, recorder.Body.String())
+}
+
+func (p *ProblemTestSuite) TestWriteError_OtherAccept_WritesApiResponse() {
+	for _, accept := range []string{"", "*/*", "application/json"} {
+		recorder := p.send(http.MethodPost, "/ratings", accept)
+
+		p.Equal(http.StatusUnprocessableEntity, recorder.Code, accept)
+		p.Equal("application/json; charset=utf-8", recorder.Header().Get("Content-Type"), accept)
+		p.JSONEq(`{"Data":null,"Message":"Rate must be at most 5","Errors":[{"Field":"Rate","Rule":"lte","Param":"5","Message":"Rate must be at most 5"}]}`, recorder.Body.String(), accept)
+	}
+}
@@ -1,8 +1,14 @@
 package api
 
+import (
+	"errors"
+	"rating-api/internal/util/validator"
+)
+
 type ApiResponse struct {
-	Data    *interface{} `json:"Data"`
-	Message string       `json:"Message"`
+	Data    *interface{}          `json:"Data"`
+	Message string                `json:"Message"`
+	Errors  []validator.Violation `json:"Errors,omitempty"`
 }
 
 func RespondOk(data interface{}) *ApiResponse {
@@ -22,3 +28,16 @@ func RespondError(message string) *ApiResponse {
 
 	return &apiResponse
 }
+
+// RespondErrorOf
+// Returns an error response for err, listing the invalid fields when err is a validation error.
+func RespondErrorOf(err error) *ApiResponse {
+	apiResponse := RespondError(err.Error())
+
+	var validationErr *validator.ValidationError
+	if errors.As(err, &validationErr) {
+		apiResponse.Errors = validationErr.Violations
+	}
+
+	return apiResponse
+}
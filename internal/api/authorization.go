@@ -0,0 +1,100 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"rating-api/internal/service/apikey"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Roles granted to the subject of a JWT by its roles claim.
+const (
+	RoleUser      = "user"
+	RoleProvider  = "provider"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Requirement
+// Declares who may call a route. A request is allowed when its JWT grants any of Roles, when its JWT grants any of
+// OwnerRoles and the OwnerParam path or query parameter equals its subject, or when its API key has any of Scopes.
+type Requirement struct {
+	Roles      []string
+	OwnerRoles []string
+	OwnerParam string
+	Scopes     []string
+}
+
+// Authorize
+// Enforces requirement on a route. Requests without a bearer token or API key are rejected with 401,
+// requests whose token or key does not satisfy requirement with 403. Must run after JwtMiddleware and ApiKeyMiddleware.
+func Authorize(requirement Requirement) gin.HandlerFunc {
+	message := requirement.describe()
+
+	return func(c *gin.Context) {
+		subject, authenticated := Subject(c)
+		client, identified := ApiClient(c)
+		if !authenticated && !identified {
+			if len(requirement.Roles) > 0 || len(requirement.OwnerRoles) > 0 {
+				c.Header("WWW-Authenticate", `Bearer`)
+			}
+			AbortWithError(c, http.StatusUnauthorized, errors.New("A bearer token or an API key is required. "+message))
+			return
+		}
+
+		if !requirement.allows(c, subject, client) {
+			AbortWithError(c, http.StatusForbidden, errors.New(message))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (r Requirement) allows(c *gin.Context, subject string, client *apikey.ApiClientModel) bool {
+	if subject != "" {
+		if HasRole(c, r.Roles...) {
+			return true
+		}
+		if HasRole(c, r.OwnerRoles...) && subject == ownerParam(c, r.OwnerParam) {
+			return true
+		}
+	}
+
+	if client != nil {
+		for _, scope := range r.Scopes {
+			if client.HasScope(scope) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// describe
+// Returns the error message of requests that do not satisfy the requirement.
+func (r Requirement) describe() string {
+	var options []string
+	if len(r.Roles) > 0 {
+		options = append(options, "the role "+strings.Join(r.Roles, " or "))
+	}
+	if len(r.OwnerRoles) > 0 {
+		options = append(options, "the role "+strings.Join(r.OwnerRoles, " or ")+" for your own "+r.OwnerParam)
+	}
+	if len(r.Scopes) > 0 {
+		options = append(options, "an API key with the scope "+strings.Join(r.Scopes, " or "))
+	}
+
+	return "Requires " + strings.Join(options, ", or ") + "."
+}
+
+func ownerParam(c *gin.Context, name string) string {
+	if value := c.Param(name); value != "" {
+		return value
+	}
+
+	return c.Query(name)
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"rating-api/internal/service/apikey"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// testCaller
+// Identity of a test request, set into the context the way JwtMiddleware and ApiKeyMiddleware do.
+type testCaller struct {
+	subject string
+	roles   []string
+	scopes  []string
+}
+
+type AuthorizationTestSuite struct {
+	suite.Suite
+	requirement Requirement
+}
+
+// Run suite.
+func TestAuthorization(t *testing.T) {
+	suite.Run(t, new(AuthorizationTestSuite))
+}
+
+// Runs before each test in the suite.
+func (a *AuthorizationTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	a.requirement = Requirement{
+		Roles:      []string{RoleModerator, RoleAdmin},
+		OwnerRoles: []string{RoleProvider},
+		OwnerParam: "providerId",
+		Scopes:     []string{apikey.ScopeRead},
+	}
+}
+
+func (a *AuthorizationTestSuite) get(target string, identity *testCaller) *httptest.ResponseRecorder {
+	router := gin.New()
+	handler := func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	}
+	identify := func(c *gin.Context) {
+		if identity == nil {
+			return
+		}
+		if identity.subject != "" {
+			c.Set(SubjectKey, identity.subject)
+			c.Set(RolesKey, identity.roles)
+		}
+		if identity.scopes != nil {
+			c.Set(ApiClientKey, &apikey.ApiClientModel{Id: 1, Scopes: identity.scopes})
+		}
+	}
+	router.GET("list", identify, Authorize(a.requirement), handler)
+	router.GET("providers/:providerId", identify, Authorize(a.requirement), handler)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	return recorder
+}
+
+func (a *AuthorizationTestSuite) TestAuthorize_NoCredentials_Returns401WithChallenge() {
+	recorder := a.get("/list", nil)
+
+	a.Equal(http.StatusUnauthorized, recorder.Code)
+	a.Equal("Bearer", recorder.Header().Get("WWW-Authenticate"))
+	a.Contains(recorder.Body.String(), "A bearer token or an API key is required.")
+}
+
+func (a *AuthorizationTestSuite) TestAuthorize_ScopesOnly_Returns401WithoutChallenge() {
+	a.requirement = Requirement{Scopes: []string{apikey.ScopeWrite}}
+
+	recorder := a.get("/list", nil)
+
+	a.Equal(http.StatusUnauthorized, recorder.Code)
+	a.Empty(recorder.Header().Get("WWW-Authenticate"))
+}
+
+func (a *AuthorizationTestSuite) TestAuthorize_Roles() {
+	tests := []struct {
+		name   string
+		roles  []string
+		status int
+	}{
+		{name: "required role", roles: []string{RoleModerator}, status: http.StatusOK},
+		{name: "one of the required roles", roles: []string{RoleUser, RoleAdmin}, status: http.StatusOK},
+		{name: "wrong role", roles: []string{RoleUser}, status: http.StatusForbidden},
+		{name: "no roles", roles: nil, status: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		recorder := a.get("/list", &testCaller{subject: "u-1", roles: test.roles})
+
+		a.Equal(test.status, recorder.Code, test.name)
+		if test.status == http.StatusForbidden {
+			a.Contains(recorder.Body.String(), "Requires the role moderator or admin", test.name)
+		}
+	}
+}
+
+func (a *AuthorizationTestSuite) TestAuthorize_OwnerRoles() {
+	tests := []struct {
+		name   string
+		target string
+		caller testCaller
+		status int
+	}{
+		{name: "own path param", target: "/providers/p-1", caller: testCaller{subject: "p-1", roles: []string{RoleProvider}}, status: http.StatusOK},
+		{name: "own query param", target: "/list?providerId=p-1", caller: testCaller{subject: "p-1", roles: []string{RoleProvider}}, status: http.StatusOK},
+		{name: "other path param", target: "/providers/p-2", caller: testCaller{subject: "p-1", roles: []string{RoleProvider}}, status: http.StatusForbidden},
+		{name: "other query param", target: "/list?providerId=p-2", caller: testCaller{subject: "p-1", roles: []string{RoleProvider}}, status: http.StatusForbidden},
+		{name: "missing param", target: "/list", caller: testCaller{subject: "p-1", roles: []string{RoleProvider}}, status: http.StatusForbidden},
+		{name: "own param without owner role", target: "/providers/p-1", caller: testCaller{subject: "p-1", roles: []string{RoleUser}}, status: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		identity := test.caller
+		recorder := a.get(test.target, &identity)
+
+		a.Equal(test.status, recorder.Code, test.name)
+	}
+}
+
+func (a *AuthorizationTestSuite) TestAuthorize_ApiKeyScopes() {
+	tests := []struct {
+		name   string
+		scopes []string
+		status int
+	}{
+		{name: "required scope", scopes: []string{apikey.ScopeRead}, status: http.StatusOK},
+		{name: "admin scope", scopes: []string{apikey.ScopeAdmin}, status: http.StatusOK},
+		{name: "other scope", scopes: []string{apikey.ScopeWrite, apikey.ScopeExport}, status: http.StatusForbidden},
+		{name: "no scopes", scopes: []string{}, status: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		recorder := a.get("/list", &testCaller{scopes: test.scopes})
+
+		a.Equal(test.status, recorder.Code, test.name)
+		if test.status == http.StatusForbidden {
+			a.Contains(recorder.Body.String(), "an API key with the scope read", test.name)
+		}
+	}
+}
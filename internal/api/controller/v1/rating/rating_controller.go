@@ -3,10 +3,14 @@ package rating
 import (
 	"net/http"
 	"rating-api/internal/api"
+	"rating-api/internal/service/apikey"
+	"rating-api/internal/service/idempotency"
 	"rating-api/internal/service/rating"
 	"rating-api/internal/util/env"
 	"rating-api/internal/util/logger"
+	"rating-api/internal/util/ratelimit"
 	"rating-api/internal/util/validator"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,15 +18,26 @@ import (
 type IRatingController interface {
 	RegisterRoutes(routerGroup *gin.RouterGroup)
 	AddRating(context *gin.Context)
+	AddRatingsBulk(context *gin.Context)
+	UpdateRating(context *gin.Context)
+	DeleteRating(context *gin.Context)
+	HideRating(context *gin.Context)
 	GetAverageRating(context *gin.Context)
+	GetAverageRatingBatch(context *gin.Context)
+	GetTopRatedProviders(context *gin.Context)
+	GetRatingDistribution(context *gin.Context)
+	ListRatings(context *gin.Context)
+	ExportRatings(context *gin.Context)
 }
 
 type RatingController struct {
-	path          string
-	environment   env.IEnvironment
-	loggr         logger.ILogger
-	validatr      validator.IValidator
-	ratingService rating.IRatingService
+	path               string
+	environment        env.IEnvironment
+	loggr              logger.ILogger
+	validatr           validator.IValidator
+	ratingService      rating.IRatingService
+	idempotencyService idempotency.IIdempotencyService
+	rateLimitStore     ratelimit.IRateLimitStore
 }
 
 // NewRatingController
@@ -32,6 +47,8 @@ func NewRatingController(
 	loggr logger.ILogger,
 	validatr validator.IValidator,
 	ratingService rating.IRatingService,
+	idempotencyService idempotency.IIdempotencyService,
+	rateLimitStore ratelimit.IRateLimitStore,
 ) IRatingController {
 	controller := RatingController{
 		path:        "rating",
@@ -46,15 +63,63 @@ func NewRatingController(
 		controller.ratingService = rating.NewRatingService(environment, loggr, validatr, nil)
 	}
 
+	if idempotencyService != nil {
+		controller.idempotencyService = idempotencyService
+	} else {
+		controller.idempotencyService = idempotency.NewIdempotencyService(environment, loggr, validatr, nil)
+	}
+
+	if rateLimitStore != nil {
+		controller.rateLimitStore = rateLimitStore
+	} else {
+		controller.rateLimitStore = ratelimit.NewMemoryStore(environment.GetInt(env.RateLimitMaxBuckets, 100000))
+	}
+
 	return &controller
 }
 
 // RegisterRoutes
-// Registers routes to gin.
+// Registers routes to gin together with who may call them and how often.
+// Users add, edit and retract their own ratings, providers list their own ratings, moderators hide ratings
+// and admins retract any rating and export. Averages, distributions and top providers are public.
 func (c *RatingController) RegisterRoutes(routerGroup *gin.RouterGroup) {
 	routes := routerGroup.Group(c.path)
-	routes.POST("add", c.AddRating)
-	routes.GET("avg", c.GetAverageRating)
+	user := api.Authorize(api.Requirement{Roles: []string{api.RoleUser}})
+	write := c.rateLimit(env.RateLimitRatingWrite, ratelimit.Limit{Requests: 60, Period: time.Minute})
+	read := c.rateLimit(env.RateLimitRatingRead, ratelimit.Limit{Requests: 600, Period: time.Minute})
+	routes.POST("add", c.rateLimit(env.RateLimitRatingAdd, ratelimit.Limit{Requests: 30, Period: time.Minute}),
+		user, api.IdempotencyMiddleware(c.idempotencyService), c.AddRating)
+	routes.POST("bulk", c.rateLimit(env.RateLimitRatingBulk, ratelimit.Limit{Requests: 10, Period: time.Minute}), api.Authorize(api.Requirement{
+		Roles:  []string{api.RoleAdmin},
+		Scopes: []string{apikey.ScopeWrite},
+	}), c.AddRatingsBulk)
+	routes.PUT(":serviceId", write, user, c.UpdateRating)
+	routes.DELETE(":serviceId", write, api.Authorize(api.Requirement{
+		Roles: []string{api.RoleUser, api.RoleAdmin},
+	}), c.DeleteRating)
+	routes.PUT(":serviceId/hidden", write, api.Authorize(api.Requirement{
+		Roles: []string{api.RoleModerator, api.RoleAdmin},
+	}), c.HideRating)
+	routes.GET("avg", read, c.GetAverageRating)
+	routes.POST("avg/batch", read, c.GetAverageRatingBatch)
+	routes.GET("top", read, c.GetTopRatedProviders)
+	routes.GET("distribution", read, c.GetRatingDistribution)
+	routes.GET("list", read, api.Authorize(api.Requirement{
+		Roles:      []string{api.RoleModerator, api.RoleAdmin},
+		OwnerRoles: []string{api.RoleProvider},
+		OwnerParam: "providerId",
+		Scopes:     []string{apikey.ScopeRead},
+	}), c.ListRatings)
+	routes.GET("export", c.rateLimit(env.RateLimitRatingExport, ratelimit.Limit{Requests: 5, Period: time.Minute}), api.Authorize(api.Requirement{
+		Roles:  []string{api.RoleAdmin},
+		Scopes: []string{apikey.ScopeExport},
+	}), c.ExportRatings)
+}
+
+// rateLimit
+// Returns the rate limiting middleware of a route with the limit of the environment variable key, or defaultLimit.
+func (c *RatingController) rateLimit(key string, defaultLimit ratelimit.Limit) gin.HandlerFunc {
+	return api.RateLimitMiddleware(c.rateLimitStore, ratelimit.LimitOf(c.environment, key, defaultLimit))
 }
 
 // AddRating
@@ -63,38 +128,245 @@ func (c *RatingController) RegisterRoutes(routerGroup *gin.RouterGroup) {
 //	@router			/v1/rating/add [post]
 //	@tags			Rating
 //	@summary		Add provider rating.
-//	@description	Add provider rating.
+//	@description	Add provider rating as the subject of the bearer token, UserName of the body is ignored.
+//	@description	Retries carrying the same Idempotency-Key header and body get the first response replayed.
+//	@description	Requires the user role.
+//	@security		BearerAuth
 //	@accept			json
 //	@produce		json
 //	@success		200		{object}	api.ApiResponse
 //	@failure		400		{object}	api.ApiResponse
 //	@failure		401		{object}	api.ApiResponse
+//	@failure		403		{object}	api.ApiResponse
+//	@failure		409		{object}	api.ApiResponse
+//	@failure		413		{object}	api.ApiResponse
+//	@failure		422		{object}	api.ApiResponse
+//	@failure		429		{object}	api.ApiResponse
 //	@failure		500		{object}	api.ApiResponse
+//	@failure		503		{object}	api.ApiResponse
 //
-//	@Param			Model	body		AddRatingModel	true	"Request model"
+//	@Param			Idempotency-Key	header		string			false	"Unique key of the request"
+//	@Param			Model			body		AddRatingModel	true	"Request model"
 func (c *RatingController) AddRating(context *gin.Context) {
+	subject, ok := api.RequireSubject(context)
+	if !ok {
+		return
+	}
+
 	var model AddRatingModel
 	err := context.ShouldBindJSON(&model)
 	if err != nil {
 		context.Error(err)
-		context.JSON(http.StatusBadRequest, api.RespondError(err.Error()))
+		api.WriteError(context, http.StatusBadRequest, err)
 		return
 	}
 
-	chRatingService := make(chan *rating.SendRatingServiceResponse)
-	defer close(chRatingService)
-
-	go c.ratingService.SendRating(chRatingService, &rating.SendRatingServiceModel{
-		UserName:   model.UserName,
+	ratingServiceResponse, err := c.ratingService.SendRating(context.Request.Context(), &rating.SendRatingServiceModel{
+		UserName:   subject,
 		ProviderId: model.ProviderId,
 		ServiceId:  model.ServiceId,
 		Rate:       model.Rate,
+		Title:      model.Title,
+		Comment:    model.Comment,
+		Criteria:   model.Criteria,
+	})
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, api.ErrorStatus(err), err)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.RespondOk(ratingServiceResponse))
+}
+
+// AddRatingsBulk
+//
+//	@basePath		/api
+//	@router			/v1/rating/bulk [post]
+//	@tags			Rating
+//	@summary		Add many provider ratings.
+//	@description	Add many provider ratings in a single transaction. Every rating is validated on its own and gets
+//	@description	a result with status "inserted", "duplicate" or "validation_error" in request order.
+//	@description	Requires the admin role or an API key with the write scope.
+//	@security		BearerAuth
+//	@security		ApiKeyAuth
+//	@accept			json
+//	@produce		json
+//	@success		200		{object}	api.ApiResponse
+//	@failure		400		{object}	api.ApiResponse
+//	@failure		401		{object}	api.ApiResponse
+//	@failure		403		{object}	api.ApiResponse
+//	@failure		422		{object}	api.ApiResponse
+//	@failure		429		{object}	api.ApiResponse
+//	@failure		500		{object}	api.ApiResponse
+//	@failure		503		{object}	api.ApiResponse
+//
+//	@Param			Model	body		AddRatingsBulkModel	true	"Request model"
+func (c *RatingController) AddRatingsBulk(context *gin.Context) {
+	var model AddRatingsBulkModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, http.StatusBadRequest, err)
+		return
+	}
+
+	serviceModel := rating.SendRatingsBulkServiceModel{Ratings: make([]rating.SendRatingServiceModel, 0, len(model.Ratings))}
+	for _, item := range model.Ratings {
+		serviceModel.Ratings = append(serviceModel.Ratings, rating.SendRatingServiceModel{
+			UserName:   item.UserName,
+			ProviderId: item.ProviderId,
+			ServiceId:  item.ServiceId,
+			Rate:       item.Rate,
+			Title:      item.Title,
+			Comment:    item.Comment,
+			Criteria:   item.Criteria,
+		})
+	}
+
+	ratingServiceResponse, err := c.ratingService.SendRatingsBulk(context.Request.Context(), &serviceModel)
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, api.ErrorStatus(err), err)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.RespondOk(ratingServiceResponse))
+}
+
+// UpdateRating
+//
+//	@basePath		/api
+//	@router			/v1/rating/{serviceId} [put]
+//	@tags			Rating
+//	@summary		Update provider rating.
+//	@description	Replace the rate, title and comment of a rating. Only the user who added the rating can change it,
+//	@description	the user is the subject of the bearer token. Requires the user role.
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@success		200			{object}	api.ApiResponse
+//	@failure		400			{object}	api.ApiResponse
+//	@failure		401			{object}	api.ApiResponse
+//	@failure		403			{object}	api.ApiResponse
+//	@failure		404			{object}	api.ApiResponse
+//	@failure		422			{object}	api.ApiResponse
+//	@failure		429			{object}	api.ApiResponse
+//	@failure		500			{object}	api.ApiResponse
+//	@failure		503			{object}	api.ApiResponse
+//
+//	@Param			serviceId	path		string				true	"Service Id"
+//	@Param			Model		body		UpdateRatingModel	true	"Request model"
+func (c *RatingController) UpdateRating(context *gin.Context) {
+	subject, ok := api.RequireSubject(context)
+	if !ok {
+		return
+	}
+
+	var model UpdateRatingModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, http.StatusBadRequest, err)
+		return
+	}
+
+	ratingServiceResponse, err := c.ratingService.UpdateRating(context.Request.Context(), &rating.UpdateRatingServiceModel{
+		UserName:  subject,
+		ServiceId: context.Param("serviceId"),
+		Rate:      model.Rate,
+		Title:     model.Title,
+		Comment:   model.Comment,
 	})
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, api.ErrorStatus(err), err)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.RespondOk(ratingServiceResponse))
+}
+
+// DeleteRating
+//
+//	@basePath		/api
+//	@router			/v1/rating/{serviceId} [delete]
+//	@tags			Rating
+//	@summary		Retract provider rating.
+//	@description	Retract a rating. Users with the user role retract only their own ratings, the subject of the bearer token.
+//	@description	Users with the admin role retract any rating.
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@success		200			{object}	api.ApiResponse
+//	@failure		400			{object}	api.ApiResponse
+//	@failure		401			{object}	api.ApiResponse
+//	@failure		403			{object}	api.ApiResponse
+//	@failure		404			{object}	api.ApiResponse
+//	@failure		422			{object}	api.ApiResponse
+//	@failure		429			{object}	api.ApiResponse
+//	@failure		500			{object}	api.ApiResponse
+//	@failure		503			{object}	api.ApiResponse
+//	@Param			serviceId	path		string	true	"Service Id"
+func (c *RatingController) DeleteRating(context *gin.Context) {
+	subject, ok := api.RequireSubject(context)
+	if !ok {
+		return
+	}
+
+	ratingServiceResponse, err := c.ratingService.DeleteRating(context.Request.Context(), &rating.DeleteRatingServiceModel{
+		UserName:  subject,
+		ServiceId: context.Param("serviceId"),
+		AnyOwner:  api.HasRole(context, api.RoleAdmin),
+	})
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, api.ErrorStatus(err), err)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.RespondOk(ratingServiceResponse))
+}
 
-	ratingServiceResponse := <-chRatingService
-	if ratingServiceResponse.Error != nil {
-		context.Error(ratingServiceResponse.Error)
-		context.JSON(http.StatusBadRequest, api.RespondError(ratingServiceResponse.Error.Error()))
+// HideRating
+//
+//	@basePath		/api
+//	@router			/v1/rating/{serviceId}/hidden [put]
+//	@tags			Rating
+//	@summary		Hide provider rating.
+//	@description	Hide a rating from averages, distributions, listings and exports, or show it again when Hidden is false.
+//	@description	Requires the moderator or admin role.
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@success		200			{object}	api.ApiResponse
+//	@failure		400			{object}	api.ApiResponse
+//	@failure		401			{object}	api.ApiResponse
+//	@failure		403			{object}	api.ApiResponse
+//	@failure		404			{object}	api.ApiResponse
+//	@failure		422			{object}	api.ApiResponse
+//	@failure		429			{object}	api.ApiResponse
+//	@failure		500			{object}	api.ApiResponse
+//	@failure		503			{object}	api.ApiResponse
+//
+//	@Param			serviceId	path		string			true	"Service Id"
+//	@Param			Model		body		HideRatingModel	true	"Request model"
+func (c *RatingController) HideRating(context *gin.Context) {
+	var model HideRatingModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, http.StatusBadRequest, err)
+		return
+	}
+
+	ratingServiceResponse, err := c.ratingService.HideRating(context.Request.Context(), &rating.HideRatingServiceModel{
+		ServiceId: context.Param("serviceId"),
+		Hidden:    model.Hidden,
+	})
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, api.ErrorStatus(err), err)
 		return
 	}
 
@@ -107,30 +379,313 @@ func (c *RatingController) AddRating(context *gin.Context) {
 //	@router			/v1/rating/avg [get]
 //	@tags			Rating
 //	@summary		Get provider's average rating.
-//	@description	Get provider's average rating.
+//	@description	Get provider's average rating. Mode "mean" is the plain average, "bayesian" pulls the average towards a configured prior
+//	@description	for providers with few ratings, "wilson" is the lower bound of the share of 4 and 5 star ratings mapped onto
+//	@description	1 to 5 stars (1 + 4 * bound) and "decay" weights ratings by age with a configured half-life.
+//	@description	AverageRate is on the 1 to 5 star scale in every mode, 0 without ratings. since, until and window restrict the ratings by creation date.
 //	@accept			json
 //	@produce		json
 //	@success		200			{object}	api.ApiResponse
 //	@failure		400			{object}	api.ApiResponse
 //	@failure		401			{object}	api.ApiResponse
+//	@failure		404			{object}	api.ApiResponse
+//	@failure		422			{object}	api.ApiResponse
+//	@failure		429			{object}	api.ApiResponse
 //	@failure		500			{object}	api.ApiResponse
+//	@failure		503			{object}	api.ApiResponse
 //	@Param			providerId	query		string	true	"Provider Id"
+//	@Param			mode		query		string	false	"Average mode"	Enums(mean, bayesian, wilson, decay)	default(mean)
+//	@Param			since		query		string	false	"Created at or after (RFC 3339)"
+//	@Param			until		query		string	false	"Created before (RFC 3339)"
+//	@Param			window		query		string	false	"Look-back window before until or now, e.g. 30d, 2w, 12h"
 func (c *RatingController) GetAverageRating(context *gin.Context) {
-	providerId := context.Query("providerId")
+	var query GetAverageRatingQueryModel
+	err := context.ShouldBindQuery(&query)
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, http.StatusBadRequest, err)
+		return
+	}
 
-	chRatingService := make(chan *rating.GetAverageRatingServiceResponse)
-	defer close(chRatingService)
+	ratingServiceResponse, err := c.ratingService.GetAverageRating(context.Request.Context(), &rating.GetAverageRatingServiceModel{
+		ProviderId: query.ProviderId,
+		Mode:       query.Mode,
+		Since:      query.Since,
+		Until:      query.Until,
+		Window:     query.Window,
+	})
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, api.ErrorStatus(err), err)
+		return
+	}
 
-	go c.ratingService.GetAverageRating(chRatingService, &rating.GetAverageRatingServiceModel{
+	context.JSON(http.StatusOK, api.RespondOk(ratingServiceResponse))
+}
+
+// GetAverageRatingBatch
+//
+//	@basePath		/api
+//	@router			/v1/rating/avg/batch [post]
+//	@tags			Rating
+//	@summary		Get average rating of many providers.
+//	@description	Get the average rating of up to 100 providers in request order. Providers without ratings have RatingCount 0.
+//	@accept			json
+//	@produce		json
+//	@success		200		{object}	api.ApiResponse
+//	@failure		400		{object}	api.ApiResponse
+//	@failure		401		{object}	api.ApiResponse
+//	@failure		422		{object}	api.ApiResponse
+//	@failure		429		{object}	api.ApiResponse
+//	@failure		500		{object}	api.ApiResponse
+//	@failure		503		{object}	api.ApiResponse
+//
+//	@Param			Model	body		GetAverageRatingBatchModel	true	"Request model"
+func (c *RatingController) GetAverageRatingBatch(context *gin.Context) {
+	var model GetAverageRatingBatchModel
+	err := context.ShouldBindJSON(&model)
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, http.StatusBadRequest, err)
+		return
+	}
+
+	ratingServiceResponse, err := c.ratingService.GetAverageRatingBatch(context.Request.Context(), &rating.GetAverageRatingBatchServiceModel{
+		ProviderIds: model.ProviderIds,
+		Mode:        model.Mode,
+	})
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, api.ErrorStatus(err), err)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.RespondOk(ratingServiceResponse))
+}
+
+// GetTopRatedProviders
+//
+//	@basePath		/api
+//	@router			/v1/rating/top [get]
+//	@tags			Rating
+//	@summary		Get top rated providers.
+//	@description	Get providers ordered by their average rating, highest first. Mode "bayesian" ranks by the bayesian average
+//	@description	so providers with few ratings do not outrank ones with many.
+//	@accept			json
+//	@produce		json
+//	@success		200			{object}	api.ApiResponse
+//	@failure		400			{object}	api.ApiResponse
+//	@failure		401			{object}	api.ApiResponse
+//	@failure		422			{object}	api.ApiResponse
+//	@failure		429			{object}	api.ApiResponse
+//	@failure		500			{object}	api.ApiResponse
+//	@failure		503			{object}	api.ApiResponse
+//	@Param			limit		query		int		false	"Number of providers (default 10, max 100)"
+//	@Param			minCount	query		int		false	"Minimum number of ratings"
+//	@Param			mode		query		string	false	"Average mode"	Enums(mean, bayesian)	default(mean)
+func (c *RatingController) GetTopRatedProviders(context *gin.Context) {
+	var query GetTopRatedProvidersQueryModel
+	err := context.ShouldBindQuery(&query)
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, http.StatusBadRequest, err)
+		return
+	}
+
+	ratingServiceResponse, err := c.ratingService.GetTopRatedProviders(context.Request.Context(), &rating.GetTopRatedProvidersServiceModel{
+		Limit:    query.Limit,
+		MinCount: query.MinCount,
+		Mode:     query.Mode,
+	})
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, api.ErrorStatus(err), err)
+		return
+	}
+
+	context.JSON(http.StatusOK, api.RespondOk(ratingServiceResponse))
+}
+
+// GetRatingDistribution
+//
+//	@basePath		/api
+//	@router			/v1/rating/distribution [get]
+//	@tags			Rating
+//	@summary		Get provider's rating distribution.
+//	@description	Get count and percentage of 1 to 5 star ratings for a provider.
+//	@accept			json
+//	@produce		json
+//	@success		200			{object}	api.ApiResponse
+//	@failure		400			{object}	api.ApiResponse
+//	@failure		401			{object}	api.ApiResponse
+//	@failure		422			{object}	api.ApiResponse
+//	@failure		429			{object}	api.ApiResponse
+//	@failure		500			{object}	api.ApiResponse
+//	@failure		503			{object}	api.ApiResponse
+//	@Param			providerId	query		string	true	"Provider Id"
+func (c *RatingController) GetRatingDistribution(context *gin.Context) {
+	providerId := context.Query("providerId")
+
+	ratingServiceResponse, err := c.ratingService.GetRatingDistribution(context.Request.Context(), &rating.GetRatingDistributionServiceModel{
 		ProviderId: providerId,
 	})
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, api.ErrorStatus(err), err)
+		return
+	}
 
-	ratingServiceResponse := <-chRatingService
-	if ratingServiceResponse.Error != nil {
-		context.Error(ratingServiceResponse.Error)
-		context.JSON(http.StatusBadRequest, api.RespondError(ratingServiceResponse.Error.Error()))
+	context.JSON(http.StatusOK, api.RespondOk(ratingServiceResponse))
+}
+
+// ListRatings
+//
+//	@basePath		/api
+//	@router			/v1/rating/list [get]
+//	@tags			Rating
+//	@summary		List provider's ratings.
+//	@description	List provider's ratings newest first. Pass NextCursor of a page as cursor to get the next page.
+//	@description	Requires the moderator or admin role, the provider role for the own providerId, or an API key with the read scope.
+//	@security		BearerAuth
+//	@security		ApiKeyAuth
+//	@accept			json
+//	@produce		json
+//	@success		200			{object}	api.ApiResponse
+//	@failure		400			{object}	api.ApiResponse
+//	@failure		401			{object}	api.ApiResponse
+//	@failure		403			{object}	api.ApiResponse
+//	@failure		422			{object}	api.ApiResponse
+//	@failure		429			{object}	api.ApiResponse
+//	@failure		500			{object}	api.ApiResponse
+//	@failure		503			{object}	api.ApiResponse
+//	@Param			providerId	query		string	true	"Provider Id"
+//	@Param			cursor		query		string	false	"NextCursor of the previous page"
+//	@Param			limit		query		int		false	"Page size (default 20, max 100)"
+//	@Param			minRate		query		int		false	"Minimum rate"
+//	@Param			maxRate		query		int		false	"Maximum rate"
+//	@Param			since		query		string	false	"Created at or after (RFC 3339)"
+//	@Param			until		query		string	false	"Created before (RFC 3339)"
+func (c *RatingController) ListRatings(context *gin.Context) {
+	var query ListRatingsQueryModel
+	err := context.ShouldBindQuery(&query)
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, http.StatusBadRequest, err)
+		return
+	}
+
+	ratingServiceResponse, err := c.ratingService.ListRatings(context.Request.Context(), &rating.ListRatingsServiceModel{
+		ProviderId: query.ProviderId,
+		Cursor:     query.Cursor,
+		Limit:      query.Limit,
+		MinRate:    query.MinRate,
+		MaxRate:    query.MaxRate,
+		Since:      query.Since,
+		Until:      query.Until,
+	})
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, api.ErrorStatus(err), err)
 		return
 	}
 
 	context.JSON(http.StatusOK, api.RespondOk(ratingServiceResponse))
 }
+
+// ExportRatings
+//
+//	@basePath		/api
+//	@router			/v1/rating/export [get]
+//	@tags			Rating
+//	@summary		Export ratings.
+//	@description	Stream all matching ratings oldest first as CSV or newline delimited JSON.
+//	@description	CSV text values starting with =, +, -, @, tab or carriage return get a ' prefix.
+//	@description	An error after the first row aborts the connection, so a cut off export never ends like a complete one.
+//	@description	Requires the admin role or an API key with the export scope.
+//	@security		BearerAuth
+//	@security		ApiKeyAuth
+//	@accept			json
+//	@produce		text/csv,application/x-ndjson
+//	@success		200			{string}	string
+//	@failure		400			{object}	api.ApiResponse
+//	@failure		401			{object}	api.ApiResponse
+//	@failure		403			{object}	api.ApiResponse
+//	@failure		422			{object}	api.ApiResponse
+//	@failure		429			{object}	api.ApiResponse
+//	@failure		500			{object}	api.ApiResponse
+//	@failure		503			{object}	api.ApiResponse
+//	@Param			providerId	query		string	false	"Provider Id"
+//	@Param			format		query		string	false	"Export format"	Enums(csv, ndjson)	default(csv)
+//	@Param			since		query		string	false	"Created at or after (RFC 3339)"
+func (c *RatingController) ExportRatings(context *gin.Context) {
+	var query ExportRatingsQueryModel
+	err := context.ShouldBindQuery(&query)
+	if err != nil {
+		context.Error(err)
+		api.WriteError(context, http.StatusBadRequest, err)
+		return
+	}
+
+	if query.Format == "" {
+		query.Format = rating.ExportFormatCsv
+	}
+
+	writer := &exportWriter{context: context, format: query.Format}
+	err = c.ratingService.ExportRatings(context.Request.Context(), &rating.ExportRatingsServiceModel{
+		ProviderId: query.ProviderId,
+		Format:     query.Format,
+		Since:      query.Since,
+	}, writer)
+	if err != nil {
+		context.Error(err)
+		if !writer.started {
+			api.WriteError(context, api.ErrorStatus(err), err)
+			return
+		}
+
+		// The 200 is already sent, aborting the connection keeps a cut off export from looking complete.
+		c.loggr.Error("Export stopped after the response was started: " + err.Error())
+		panic(http.ErrAbortHandler)
+	}
+
+	if !writer.started {
+		writer.start()
+	}
+}
+
+// exportWriter
+// Sends export headers on the first write and flushes every write as a chunk,
+// so errors before any output can still be answered with an ApiResponse.
+type exportWriter struct {
+	context *gin.Context
+	format  string
+	started bool
+}
+
+func (w *exportWriter) start() {
+	w.started = true
+
+	contentType := "text/csv; charset=utf-8"
+	if w.format == rating.ExportFormatNdjson {
+		contentType = "application/x-ndjson"
+	}
+
+	w.context.Header("Content-Type", contentType)
+	w.context.Header("Content-Disposition", `attachment; filename="ratings.`+w.format+`"`)
+	w.context.Status(http.StatusOK)
+	w.context.Writer.WriteHeaderNow()
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.start()
+	}
+
+	n, err := w.context.Writer.Write(p)
+	if err != nil {
+		return n, err
+	}
+	w.context.Writer.Flush()
+
+	return n, nil
+}
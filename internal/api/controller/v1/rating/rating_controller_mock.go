@@ -46,6 +46,42 @@ func (mr *MockIRatingControllerMockRecorder) AddRating(context interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRating", reflect.TypeOf((*MockIRatingController)(nil).AddRating), context)
 }
 
+// AddRatingsBulk mocks base method.
+func (m *MockIRatingController) AddRatingsBulk(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddRatingsBulk", context)
+}
+
+// AddRatingsBulk indicates an expected call of AddRatingsBulk.
+func (mr *MockIRatingControllerMockRecorder) AddRatingsBulk(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRatingsBulk", reflect.TypeOf((*MockIRatingController)(nil).AddRatingsBulk), context)
+}
+
+// DeleteRating mocks base method.
+func (m *MockIRatingController) DeleteRating(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteRating", context)
+}
+
+// DeleteRating indicates an expected call of DeleteRating.
+func (mr *MockIRatingControllerMockRecorder) DeleteRating(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRating", reflect.TypeOf((*MockIRatingController)(nil).DeleteRating), context)
+}
+
+// ExportRatings mocks base method.
+func (m *MockIRatingController) ExportRatings(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ExportRatings", context)
+}
+
+// ExportRatings indicates an expected call of ExportRatings.
+func (mr *MockIRatingControllerMockRecorder) ExportRatings(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportRatings", reflect.TypeOf((*MockIRatingController)(nil).ExportRatings), context)
+}
+
 // GetAverageRating mocks base method.
 func (m *MockIRatingController) GetAverageRating(context *gin.Context) {
 	m.ctrl.T.Helper()
@@ -58,6 +94,54 @@ func (mr *MockIRatingControllerMockRecorder) GetAverageRating(context interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageRating", reflect.TypeOf((*MockIRatingController)(nil).GetAverageRating), context)
 }
 
+// GetAverageRatingBatch mocks base method.
+func (m *MockIRatingController) GetAverageRatingBatch(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAverageRatingBatch", context)
+}
+
+// GetAverageRatingBatch indicates an expected call of GetAverageRatingBatch.
+func (mr *MockIRatingControllerMockRecorder) GetAverageRatingBatch(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageRatingBatch", reflect.TypeOf((*MockIRatingController)(nil).GetAverageRatingBatch), context)
+}
+
+// GetRatingDistribution mocks base method.
+func (m *MockIRatingController) GetRatingDistribution(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetRatingDistribution", context)
+}
+
+// GetRatingDistribution indicates an expected call of GetRatingDistribution.
+func (mr *MockIRatingControllerMockRecorder) GetRatingDistribution(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingDistribution", reflect.TypeOf((*MockIRatingController)(nil).GetRatingDistribution), context)
+}
+
+// GetTopRatedProviders mocks base method.
+func (m *MockIRatingController) GetTopRatedProviders(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetTopRatedProviders", context)
+}
+
+// GetTopRatedProviders indicates an expected call of GetTopRatedProviders.
+func (mr *MockIRatingControllerMockRecorder) GetTopRatedProviders(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopRatedProviders", reflect.TypeOf((*MockIRatingController)(nil).GetTopRatedProviders), context)
+}
+
+// ListRatings mocks base method.
+func (m *MockIRatingController) ListRatings(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListRatings", context)
+}
+
+// ListRatings indicates an expected call of ListRatings.
+func (mr *MockIRatingControllerMockRecorder) ListRatings(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRatings", reflect.TypeOf((*MockIRatingController)(nil).ListRatings), context)
+}
+
 // RegisterRoutes mocks base method.
 func (m *MockIRatingController) RegisterRoutes(routerGroup *gin.RouterGroup) {
 	m.ctrl.T.Helper()
@@ -69,3 +153,15 @@ func (mr *MockIRatingControllerMockRecorder) RegisterRoutes(routerGroup interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterRoutes", reflect.TypeOf((*MockIRatingController)(nil).RegisterRoutes), routerGroup)
 }
+
+// UpdateRating mocks base method.
+func (m *MockIRatingController) UpdateRating(context *gin.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateRating", context)
+}
+
+// UpdateRating indicates an expected call of UpdateRating.
+func (mr *MockIRatingControllerMockRecorder) UpdateRating(context interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRating", reflect.TypeOf((*MockIRatingController)(nil).UpdateRating), context)
+}
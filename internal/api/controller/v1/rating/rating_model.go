@@ -1,8 +1,64 @@
 package rating
 
+import "time"
+
+// AddRatingModel
+// UserName is only read by the bulk endpoint, a single add always uses the token subject.
 type AddRatingModel struct {
-	UserName   string `json:"UserName"`
-	ProviderId string `json:"ProviderId"`
-	ServiceId  string `json:"ServiceId"`
-	Rate       int    `json:"Rate"`
+	UserName   string         `json:"UserName"`
+	ProviderId string         `json:"ProviderId"`
+	ServiceId  string         `json:"ServiceId"`
+	Rate       int            `json:"Rate"`
+	Title      string         `json:"Title"`
+	Comment    string         `json:"Comment"`
+	Criteria   map[string]int `json:"Criteria"`
+}
+
+type AddRatingsBulkModel struct {
+	Ratings []AddRatingModel `json:"Ratings"`
+}
+
+type UpdateRatingModel struct {
+	Rate    int    `json:"Rate"`
+	Title   string `json:"Title"`
+	Comment string `json:"Comment"`
+}
+
+type HideRatingModel struct {
+	Hidden bool `json:"Hidden"`
+}
+
+type ListRatingsQueryModel struct {
+	ProviderId string    `form:"providerId"`
+	Cursor     string    `form:"cursor"`
+	Limit      int       `form:"limit"`
+	MinRate    int       `form:"minRate"`
+	MaxRate    int       `form:"maxRate"`
+	Since      time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until      time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type GetAverageRatingQueryModel struct {
+	ProviderId string    `form:"providerId"`
+	Mode       string    `form:"mode"`
+	Since      time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until      time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+	Window     string    `form:"window"`
+}
+
+type GetAverageRatingBatchModel struct {
+	ProviderIds []string `json:"ProviderIds"`
+	Mode        string   `json:"Mode"`
+}
+
+type ExportRatingsQueryModel struct {
+	ProviderId string    `form:"providerId"`
+	Format     string    `form:"format"`
+	Since      time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type GetTopRatedProvidersQueryModel struct {
+	Limit    int    `form:"limit"`
+	MinCount int    `form:"minCount"`
+	Mode     string `form:"mode"`
 }
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"rating-api/internal/util/env"
+	"rating-api/internal/util/logger"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SubjectKey
+// Context key of the verified JWT subject.
+const SubjectKey = "subject"
+
+// RolesKey
+// Context key of the roles of the verified JWT.
+const RolesKey = "roles"
+
+var errNoJwtKeys = errors.New("no JWT keys are configured")
+
+// JwtMiddleware
+// Identifies callers sending an "Authorization: Bearer <token>" header carrying a valid HS256 or RS256 signed JWT
+// with an expiry and a subject, and puts the subject and roles into the context under SubjectKey and RolesKey.
+// Tokens without a roles claim get the user role.
+// HS256 tokens are verified with AUTH_JWT_SECRET and RS256 tokens with the keys in AUTH_JWT_JWKS_FILE.
+// Requests with an invalid token are rejected with 401, requests without the header are passed through.
+func JwtMiddleware(environment env.IEnvironment, loggr logger.ILogger) gin.HandlerFunc {
+	verifier, err := newJwtVerifier(environment)
+	if err != nil {
+		loggr.Error(err.Error())
+		panic("Panicked while loading JWT keys.")
+	}
+	if verifier.secret == nil && len(verifier.publicKeys) == 0 {
+		loggr.Warn("Every bearer token is rejected: " + errNoJwtKeys.Error())
+	}
+
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+			c.Header("WWW-Authenticate", `Bearer`)
+			AbortWithError(c, http.StatusUnauthorized, errors.New("Authorization header must carry a bearer token."))
+			return
+		}
+
+		claims, err := verifier.verify(strings.TrimSpace(header[7:]))
+		if err != nil {
+			c.Error(err)
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			AbortWithError(c, http.StatusUnauthorized, errors.New("Bearer token is invalid."))
+			return
+		}
+
+		roles := claims.Roles
+		if len(roles) == 0 {
+			roles = []string{RoleUser}
+		}
+
+		c.Set(SubjectKey, claims.Subject)
+		c.Set(RolesKey, roles)
+		c.Next()
+	}
+}
+
+// Subject
+// Returns the verified JWT subject of the request.
+func Subject(c *gin.Context) (string, bool) {
+	subject := c.GetString(SubjectKey)
+	return subject, subject != ""
+}
+
+// RequireSubject
+// Returns the verified JWT subject of the request, or answers 401 and returns false when it has none.
+func RequireSubject(c *gin.Context) (string, bool) {
+	subject, ok := Subject(c)
+	if !ok {
+		c.Header("WWW-Authenticate", `Bearer`)
+		WriteError(c, http.StatusUnauthorized, errors.New("Authorization header with a bearer token is required."))
+	}
+
+	return subject, ok
+}
+
+// HasRole
+// Reports whether the verified JWT of the request grants any of roles.
+func HasRole(c *gin.Context, roles ...string) bool {
+	for _, granted := range c.GetStringSlice(RolesKey) {
+		for _, role := range roles {
+			if granted == role {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// jwtClaims
+// Registered claims with the roles granted to the subject.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles"`
+}
+
+type jwtVerifier struct {
+	secret     []byte
+	publicKeys map[string]*rsa.PublicKey
+	parser     *jwt.Parser
+}
+
+func newJwtVerifier(environment env.IEnvironment) (*jwtVerifier, error) {
+	verifier := jwtVerifier{publicKeys: make(map[string]*rsa.PublicKey)}
+
+	if secret := environment.Get(env.AuthJwtSecret); secret != "" {
+		verifier.secret = []byte(secret)
+	}
+
+	if path := environment.Get(env.AuthJwtJwksFile); path != "" {
+		publicKeys, err := readJwks(path)
+		if err != nil {
+			return nil, err
+		}
+		verifier.publicKeys = publicKeys
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if issuer := environment.Get(env.AuthJwtIssuer); issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience := environment.Get(env.AuthJwtAudience); audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+	verifier.parser = jwt.NewParser(options...)
+
+	return &verifier, nil
+}
+
+// verify
+// Returns the claims of a valid token.
+func (v *jwtVerifier) verify(tokenString string) (*jwtClaims, error) {
+	var claims jwtClaims
+	_, err := v.parser.ParseWithClaims(tokenString, &claims, v.key)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	return &claims, nil
+}
+
+// key
+// Picks the verification key by the signing method, and by the kid header for RS256.
+// A JWKS with a single key is used for tokens without a kid.
+func (v *jwtVerifier) key(token *jwt.Token) (interface{}, error) {
+	switch token.Method {
+	case jwt.SigningMethodHS256:
+		if v.secret == nil {
+			return nil, errNoJwtKeys
+		}
+		return v.secret, nil
+	case jwt.SigningMethodRS256:
+		kid, _ := token.Header["kid"].(string)
+		if publicKey, ok := v.publicKeys[kid]; ok {
+			return publicKey, nil
+		}
+		if kid == "" && len(v.publicKeys) == 1 {
+			for _, publicKey := range v.publicKeys {
+				return publicKey, nil
+			}
+		}
+		return nil, fmt.Errorf("no JWKS key with kid %q", kid)
+	}
+
+	return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+}
+
+type jwks struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		Alg string `json:"alg"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// readJwks
+// Returns the RSA signing keys of the JWKS file by kid. Keys of other types or uses are skipped.
+func readJwks(path string) (map[string]*rsa.PublicKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var set jwks
+	if err := json.Unmarshal(content, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS file %s: %w", path, err)
+	}
+
+	publicKeys := make(map[string]*rsa.PublicKey)
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") || (key.Alg != "" && key.Alg != jwt.SigningMethodRS256.Alg()) {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus of JWKS key %q: %w", key.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent of JWKS key %q: %w", key.Kid, err)
+		}
+
+		publicKeys[key.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	if len(publicKeys) == 0 {
+		return nil, fmt.Errorf("JWKS file %s has no RS256 signing keys", path)
+	}
+
+	return publicKeys, nil
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"rating-api/internal/util/env"
+	"rating-api/internal/util/logger"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testJwtSecret   = "test-secret"
+	testJwtIssuer   = "https://issuer.test"
+	testJwtAudience = "rating-api"
+)
+
+type JwtTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	privateKey *rsa.PrivateKey
+}
+
+// Run suite.
+func TestJwt(t *testing.T) {
+	suite.Run(t, new(JwtTestSuite))
+}
+
+// Runs before each test in the suite.
+func (j *JwtTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	j.Require().Nil(err)
+	j.privateKey = privateKey
+
+	jwksFile := filepath.Join(j.T().TempDir(), "jwks.json")
+	jwks := map[string]interface{}{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": "key-1",
+		"use": "sig",
+		"alg": "RS256",
+		"n":   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+	}}}
+	content, err := json.Marshal(jwks)
+	j.Require().Nil(err)
+	j.Require().Nil(os.WriteFile(jwksFile, content, 0600))
+
+	ctrl := gomock.NewController(j.T())
+	mockEnvironment := env.NewMockIEnvironment(ctrl)
+	values := map[string]string{
+		env.AuthJwtSecret:   testJwtSecret,
+		env.AuthJwtJwksFile: jwksFile,
+		env.AuthJwtIssuer:   testJwtIssuer,
+		env.AuthJwtAudience: testJwtAudience,
+	}
+	mockEnvironment.EXPECT().Get(gomock.Any()).DoAndReturn(func(key string) string { return values[key] }).AnyTimes()
+
+	j.router = gin.New()
+	j.router.Use(JwtMiddleware(mockEnvironment, logger.NewMockILogger(ctrl)))
+	j.router.GET("me", func(c *gin.Context) {
+		subject, ok := RequireSubject(c)
+		if !ok {
+			return
+		}
+		c.JSON(http.StatusOK, RespondOk(map[string]interface{}{"subject": subject, "roles": c.GetStringSlice(RolesKey)}))
+	})
+}
+
+func (j *JwtTestSuite) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": "emre.bilal",
+		"iss": testJwtIssuer,
+		"aud": testJwtAudience,
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func (j *JwtTestSuite) sign(method jwt.SigningMethod, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	var key interface{} = []byte(testJwtSecret)
+	switch method {
+	case jwt.SigningMethodRS256:
+		key = j.privateKey
+	case jwt.SigningMethodNone:
+		key = jwt.UnsafeAllowNoneSignatureType
+	}
+
+	signed, err := token.SignedString(key)
+	j.Require().Nil(err)
+	return signed
+}
+
+func (j *JwtTestSuite) get(authorization string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, "/me", nil)
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+
+	recorder := httptest.NewRecorder()
+	j.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func (j *JwtTestSuite) TestJwtMiddleware() {
+	without := func(claim string) jwt.MapClaims {
+		claims := j.claims()
+		delete(claims, claim)
+		return claims
+	}
+	with := func(claim string, value interface{}) jwt.MapClaims {
+		claims := j.claims()
+		claims[claim] = value
+		return claims
+	}
+
+	tests := []struct {
+		name   string
+		token  func() string
+		status int
+	}{
+		{name: "HS256 valid", token: func() string { return j.sign(jwt.SigningMethodHS256, "", j.claims()) }, status: http.StatusOK},
+		{name: "RS256 valid", token: func() string { return j.sign(jwt.SigningMethodRS256, "key-1", j.claims()) }, status: http.StatusOK},
+		{name: "RS256 without kid uses the only key", token: func() string { return j.sign(jwt.SigningMethodRS256, "", j.claims()) }, status: http.StatusOK},
+		{name: "HS256 expired", token: func() string {
+			return j.sign(jwt.SigningMethodHS256, "", with("exp", time.Now().Add(-time.Hour).Unix()))
+		}, status: http.StatusUnauthorized},
+		{name: "RS256 expired", token: func() string {
+			return j.sign(jwt.SigningMethodRS256, "key-1", with("exp", time.Now().Add(-time.Hour).Unix()))
+		}, status: http.StatusUnauthorized},
+		{name: "HS256 without exp", token: func() string { return j.sign(jwt.SigningMethodHS256, "", without("exp")) }, status: http.StatusUnauthorized},
+		{name: "HS256 wrong issuer", token: func() string {
+			return j.sign(jwt.SigningMethodHS256, "", with("iss", "https://other.test"))
+		}, status: http.StatusUnauthorized},
+		{name: "RS256 wrong issuer", token: func() string {
+			return j.sign(jwt.SigningMethodRS256, "key-1", with("iss", "https://other.test"))
+		}, status: http.StatusUnauthorized},
+		{name: "HS256 wrong audience", token: func() string { return j.sign(jwt.SigningMethodHS256, "", with("aud", "other-api")) }, status: http.StatusUnauthorized},
+		{name: "RS256 wrong audience", token: func() string {
+			return j.sign(jwt.SigningMethodRS256, "key-1", with("aud", "other-api"))
+		}, status: http.StatusUnauthorized},
+		{name: "HS256 missing sub", token: func() string { return j.sign(jwt.SigningMethodHS256, "", without("sub")) }, status: http.StatusUnauthorized},
+		{name: "RS256 missing sub", token: func() string { return j.sign(jwt.SigningMethodRS256, "key-1", without("sub")) }, status: http.StatusUnauthorized},
+		{name: "alg none", token: func() string { return j.sign(jwt.SigningMethodNone, "", j.claims()) }, status: http.StatusUnauthorized},
+		{name: "RS256 kid not in JWKS", token: func() string { return j.sign(jwt.SigningMethodRS256, "key-2", j.claims()) }, status: http.StatusUnauthorized},
+		{name: "HS256 wrong secret", token: func() string {
+			signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, j.claims()).SignedString([]byte("other-secret"))
+			j.Require().Nil(err)
+			return signed
+		}, status: http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		j.Run(test.name, func() {
+			recorder := j.get("Bearer " + test.token())
+
+			j.Equal(test.status, recorder.Code, recorder.Body.String())
+			if test.status == http.StatusUnauthorized {
+				j.Equal(`Bearer error="invalid_token"`, recorder.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func (j *JwtTestSuite) TestJwtMiddleware_RolesClaim_SetsRoles() {
+	recorder := j.get("Bearer " + j.sign(jwt.SigningMethodHS256, "", j.claims()))
+	j.Contains(recorder.Body.String(), `"roles":["user"]`)
+
+	claims := j.claims()
+	claims["roles"] = []string{RoleModerator}
+	recorder = j.get("Bearer " + j.sign(jwt.SigningMethodHS256, "", claims))
+	j.Contains(recorder.Body.String(), `"roles":["moderator"]`)
+}
+
+func (j *JwtTestSuite) TestRequireSubject_NoToken_Returns401() {
+	recorder := j.get("")
+
+	j.Equal(http.StatusUnauthorized, recorder.Code)
+	j.Equal("Bearer", recorder.Header().Get("WWW-Authenticate"))
+}
+
+func (j *JwtTestSuite) TestJwtMiddleware_NotBearer_Returns401() {
+	recorder := j.get("Basic dXNlcjpwYXNz")
+
+	j.Equal(http.StatusUnauthorized, recorder.Code)
+}
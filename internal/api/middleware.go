@@ -1,8 +1,18 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"rating-api/internal/service/apikey"
+	"rating-api/internal/service/idempotency"
 	"rating-api/internal/util/logger"
+	"rating-api/internal/util/metrics"
+	"rating-api/internal/util/ratelimit"
 	"strconv"
 	"time"
 
@@ -30,6 +40,11 @@ func LoggingMiddleware(loggr logger.ILogger) gin.HandlerFunc {
 		statusCode := c.Writer.Status()
 		hasError := len(c.Errors.Errors()) > 0
 
+		var client string
+		if apiClient, ok := ApiClient(c); ok {
+			client = apiClient.Name
+		}
+
 		var errs []error
 		if hasError {
 			for i := 0; i < len(c.Errors.Errors()); i++ {
@@ -38,7 +53,8 @@ func LoggingMiddleware(loggr logger.ILogger) gin.HandlerFunc {
 		}
 
 		pingRoute := "/api/ping"
-		if route != pingRoute {
+		metricsRoute := "/metrics"
+		if route != pingRoute && route != metricsRoute {
 			logMessage := protocol + " " + method + " " + uri + " responded " + strconv.Itoa(statusCode) + " in " + strconv.Itoa(int(elapsedMilliseconds)) + " ms"
 
 			if hasError {
@@ -51,6 +67,7 @@ func LoggingMiddleware(loggr logger.ILogger) gin.HandlerFunc {
 					zap.String("method", method),
 					zap.String("remoteAddr", remoteAddr),
 					zap.String("clientIp", clientIp),
+					zap.String("client", client),
 					zap.String("queryString", queryString),
 					zap.Int("statusCode", statusCode),
 					zap.Int64("elapsedMilliseconds", elapsedMilliseconds),
@@ -66,6 +83,7 @@ func LoggingMiddleware(loggr logger.ILogger) gin.HandlerFunc {
 					zap.String("method", method),
 					zap.String("remoteAddr", remoteAddr),
 					zap.String("clientIp", clientIp),
+					zap.String("client", client),
 					zap.String("queryString", queryString),
 					zap.Int("statusCode", statusCode),
 					zap.Int64("elapsedMilliseconds", elapsedMilliseconds),
@@ -75,3 +93,211 @@ func LoggingMiddleware(loggr logger.ILogger) gin.HandlerFunc {
 		}
 	}
 }
+
+// MetricsMiddleware
+// Records the count and latency of HTTP requests by route, method and status code.
+// Requests that match no route are recorded under the "unmatched" route.
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.ObserveRequest(route, c.Request.Method, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// maxIdempotentBodyBytes
+// Largest request body IdempotencyMiddleware reads into memory to hash and replay.
+const maxIdempotentBodyBytes = 1 << 20
+
+// IdempotencyMiddleware
+// Replays the stored response when a client repeats a request with the same Idempotency-Key header and body.
+// A key reused with a different body is answered with 422, a key whose first request is still running with 409
+// and a body over maxIdempotentBodyBytes with 413. Requests without the header are passed through.
+func IdempotencyMiddleware(idempotencyService idempotency.IIdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxIdempotentBodyBytes))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.Error(err)
+			AbortWithError(c, http.StatusRequestEntityTooLarge, fmt.Errorf("Request body must be at most %d bytes.", tooLarge.Limit))
+			return
+		}
+		if err != nil {
+			c.Error(err)
+			AbortWithError(c, http.StatusBadRequest, err)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Keys are kept per client, another client sending the same key neither collides with nor learns about it.
+		scope := c.Request.Method + " " + c.FullPath()
+		subject := caller(c)
+		response, err := idempotencyService.Begin(c.Request.Context(), &idempotency.BeginRequestServiceModel{
+			Scope:   scope,
+			Key:     key,
+			Subject: subject,
+			Body:    body,
+		})
+		if err != nil {
+			c.Error(err)
+			AbortWithError(c, ErrorStatus(err), err)
+			return
+		}
+
+		if response.Replay != nil {
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(response.Replay.StatusCode, response.Replay.ContentType, response.Replay.Body)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		c.Next()
+
+		// The request context is done once the client goes away, the response is stored regardless.
+		_, err = idempotencyService.Finish(context.Background(), &idempotency.FinishRequestServiceModel{
+			Scope:       scope,
+			Subject:     subject,
+			Key:         key,
+			StatusCode:  recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		})
+		if err != nil {
+			c.Error(err)
+		}
+	}
+}
+
+// responseRecorder
+// Keeps a copy of the response body while writing it to the client.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.body.Write(p)
+	return r.ResponseWriter.Write(p)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+// ApiClientKey
+// Context key of the *apikey.ApiClientModel of a request made with an API key.
+const ApiClientKey = "apiClient"
+
+// ApiKeyMiddleware
+// Identifies callers sending an API key in the X-Api-Key header and puts the client into the context under ApiClientKey.
+// Requests with an unknown or revoked key are rejected with 401, requests without the header are passed through.
+func ApiKeyMiddleware(apiKeyService apikey.IApiKeyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("X-Api-Key")
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		response, err := apiKeyService.Authenticate(c.Request.Context(), &apikey.AuthenticateServiceModel{Key: key})
+		if errors.Is(err, apikey.ErrKeyInvalid) {
+			c.Error(err)
+			AbortWithError(c, http.StatusUnauthorized, err)
+			return
+		}
+		if err != nil {
+			c.Error(err)
+			AbortWithError(c, ErrorStatus(err), err)
+			return
+		}
+
+		c.Set(ApiClientKey, &response.Client)
+		c.Next()
+	}
+}
+
+// ApiClient
+// Returns the client of a request made with an API key.
+func ApiClient(c *gin.Context) (*apikey.ApiClientModel, bool) {
+	value, ok := c.Get(ApiClientKey)
+	if !ok {
+		return nil, false
+	}
+
+	client, ok := value.(*apikey.ApiClientModel)
+	return client, ok
+}
+
+// RateLimitMiddleware
+// Limits requests to the route per caller with a token bucket kept in store. Callers are told apart by API key,
+// then JWT subject, then client IP, so it must run after JwtMiddleware and ApiKeyMiddleware.
+// Responses carry RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy headers,
+// requests over the limit are rejected with 429 and a Retry-After header. Requests are let through when the store fails.
+func RateLimitMiddleware(store ratelimit.IRateLimitStore, limit ratelimit.Limit) gin.HandlerFunc {
+	if limit.Requests <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	policy := fmt.Sprintf("%d;w=%d", limit.Requests, ceilSeconds(limit.Period))
+
+	return func(c *gin.Context) {
+		key := c.Request.Method + " " + c.FullPath() + " " + caller(c)
+		result, err := store.Take(c.Request.Context(), key, limit)
+		if err != nil {
+			c.Error(err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+		c.Header("RateLimit-Policy", policy)
+
+		if !result.Allowed {
+			retryAfter := ceilSeconds(result.RetryAfter)
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			AbortWithError(c, http.StatusTooManyRequests,
+				fmt.Errorf("Rate limit of %d requests per %d seconds exceeded, retry in %d seconds.",
+					limit.Requests, ceilSeconds(limit.Period), retryAfter))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// caller
+// Identifies the client of a request for rate limits and idempotency keys: its API key, its JWT subject or its IP address.
+func caller(c *gin.Context) string {
+	if client, ok := ApiClient(c); ok {
+		return "key:" + strconv.FormatInt(client.Id, 10)
+	}
+	if subject, ok := Subject(c); ok {
+		return "user:" + subject
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"rating-api/internal/service/apikey"
+	"rating-api/internal/service/idempotency"
+	"rating-api/internal/util/ratelimit"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MiddlewareTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+// Run suite.
+func TestMiddleware(t *testing.T) {
+	suite.Run(t, new(MiddlewareTestSuite))
+}
+
+// Runs before each test in the suite.
+func (m *MiddlewareTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	m.router = gin.New()
+	m.Require().Nil(m.router.SetTrustedProxies(nil))
+	m.router.POST("add", RateLimitMiddleware(ratelimit.NewMemoryStore(10), ratelimit.Limit{Requests: 2, Period: time.Minute}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+}
+
+func (m *MiddlewareTestSuite) post(remoteAddr string, forwardedFor string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/add", nil)
+	request.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		request.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+
+	recorder := httptest.NewRecorder()
+	m.router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func (m *MiddlewareTestSuite) TestRateLimitMiddleware_OverLimit_Returns429WithRetryAfter() {
+	tests := []struct {
+		status    int
+		remaining string
+	}{
+		{status: http.StatusOK, remaining: "1"},
+		{status: http.StatusOK, remaining: "0"},
+		{status: http.StatusTooManyRequests, remaining: "0"},
+	}
+
+	for i, test := range tests {
+		recorder := m.post("10.0.0.1:1234", "")
+
+		m.Equal(test.status, recorder.Code, "request %d", i)
+		m.Equal("2", recorder.Header().Get("RateLimit-Limit"), "request %d", i)
+		m.Equal(test.remaining, recorder.Header().Get("RateLimit-Remaining"), "request %d", i)
+		m.Equal("2;w=60", recorder.Header().Get("RateLimit-Policy"), "request %d", i)
+		if test.status == http.StatusTooManyRequests {
+			m.Equal("30", recorder.Header().Get("Retry-After"))
+			m.Contains(recorder.Body.String(), "retry in 30 seconds")
+		} else {
+			m.Empty(recorder.Header().Get("Retry-After"))
+		}
+	}
+}
+
+func (m *MiddlewareTestSuite) TestRateLimitMiddleware_ForwardedFor_IsIgnoredWithoutTrustedProxies() {
+	for _, forwardedFor := range []string{"1.1.1.1", "2.2.2.2"} {
+		m.Equal(http.StatusOK, m.post("10.0.0.1:1234", forwardedFor).Code)
+	}
+
+	m.Equal(http.StatusTooManyRequests, m.post("10.0.0.1:1234", "3.3.3.3").Code)
+	m.Equal(http.StatusOK, m.post("10.0.0.2:1234", "").Code)
+}
+
+func (m *MiddlewareTestSuite) TestIdempotencyMiddleware_SameKeyOfTwoClients_IsKeptApart() {
+	mockIdempotencyService := idempotency.NewMockIIdempotencyService(gomock.NewController(m.T()))
+	router := gin.New()
+	router.POST("add", func(c *gin.Context) {
+		if subject := c.GetHeader("Test-Subject"); subject != "" {
+			c.Set(SubjectKey, subject)
+		}
+		if c.GetHeader("Test-Api-Key") != "" {
+			c.Set(ApiClientKey, &apikey.ApiClientModel{Id: 7})
+		}
+	}, IdempotencyMiddleware(mockIdempotencyService), func(c *gin.Context) {
+		c.String(http.StatusOK, "added")
+	})
+
+	for _, subject := range []string{"user:u-1", "user:u-2", "key:7"} {
+		mockIdempotencyService.
+			EXPECT().
+			Begin(gomock.Any(), gomock.Eq(&idempotency.BeginRequestServiceModel{Scope: "POST /add", Key: "k-1", Subject: subject, Body: []byte(`{}`)})).
+			Return(&idempotency.BeginRequestServiceResponse{}, nil)
+		mockIdempotencyService.
+			EXPECT().
+			Finish(gomock.Any(), gomock.Eq(&idempotency.FinishRequestServiceModel{
+				Scope:       "POST /add",
+				Subject:     subject,
+				Key:         "k-1",
+				StatusCode:  http.StatusOK,
+				ContentType: "text/plain; charset=utf-8",
+				Body:        []byte("added"),
+			})).
+			Return(&idempotency.FinishRequestServiceResponse{Stored: true}, nil)
+	}
+
+	for _, header := range []string{"Test-Subject: u-1", "Test-Subject: u-2", "Test-Api-Key: 1"} {
+		name, value, _ := strings.Cut(header, ": ")
+		request := httptest.NewRequest(http.MethodPost, "/add", strings.NewReader(`{}`))
+		request.Header.Set("Idempotency-Key", "k-1")
+		request.Header.Set(name, value)
+
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+
+		m.Equal(http.StatusOK, recorder.Code, header)
+	}
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"rating-api/internal/service/apikey"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const apiKeyUsage = "usage: rating-api apikey create --name job --scopes read,write | revoke --id 1 | list [--all]"
+
+// ApiKey
+// Runs "rating-api apikey create|revoke|list" to manage the API keys of service-to-service callers.
+// Returns 0 on success, 1 when the command failed and 2 on usage errors.
+func ApiKey(args []string, apiKeyService apikey.IApiKeyService, stdout io.Writer) int {
+	if len(args) < 1 {
+		fmt.Fprintln(stdout, apiKeyUsage)
+		return 2
+	}
+
+	ctx := context.Background()
+	flags := flag.NewFlagSet("apikey "+args[0], flag.ContinueOnError)
+	flags.SetOutput(stdout)
+
+	switch args[0] {
+	case "create":
+		name := flags.String("name", "", "name of the calling client")
+		scopes := flags.String("scopes", apikey.ScopeRead, "comma separated scopes out of read, write, export and admin")
+		if err := flags.Parse(args[1:]); err != nil {
+			return 2
+		}
+
+		response, err := apiKeyService.CreateKey(ctx, &apikey.CreateKeyServiceModel{
+			Name:   *name,
+			Scopes: strings.Split(*scopes, ","),
+		})
+		if err != nil {
+			fmt.Fprintln(stdout, "apikey: "+err.Error())
+			return 1
+		}
+
+		fmt.Fprintf(stdout, "created API key %d, it is shown only once:\n%s\n", response.Id, response.Key)
+	case "revoke":
+		id := flags.Int64("id", 0, "id of the key")
+		if err := flags.Parse(args[1:]); err != nil {
+			return 2
+		}
+
+		response, err := apiKeyService.RevokeKey(ctx, &apikey.RevokeKeyServiceModel{Id: *id})
+		if err != nil {
+			fmt.Fprintln(stdout, "apikey: "+err.Error())
+			return 1
+		}
+
+		fmt.Fprintln(stdout, response.Info)
+	case "list":
+		all := flags.Bool("all", false, "include revoked keys")
+		if err := flags.Parse(args[1:]); err != nil {
+			return 2
+		}
+
+		response, err := apiKeyService.ListKeys(ctx, &apikey.ListKeysServiceModel{IncludeRevoked: *all})
+		if err != nil {
+			fmt.Fprintln(stdout, "apikey: "+err.Error())
+			return 1
+		}
+
+		table := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "ID\tNAME\tPREFIX\tSCOPES\tCREATED\tLAST USED\tREVOKED")
+		for _, key := range response.Keys {
+			fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", key.Id, key.Name, key.Prefix, strings.Join(key.Scopes, ","),
+				key.CreatedDate.Format(time.RFC3339), formatOptionalDate(key.LastUsedDate), formatOptionalDate(key.RevokedDate))
+		}
+		table.Flush()
+	default:
+		fmt.Fprintln(stdout, apiKeyUsage)
+		return 2
+	}
+
+	return 0
+}
+
+func formatOptionalDate(date *time.Time) string {
+	if date == nil {
+		return "-"
+	}
+
+	return date.Format(time.RFC3339)
+}
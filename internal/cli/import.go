@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"rating-api/internal/service/rating"
+	"rating-api/internal/util/env"
+	"strconv"
+	"strings"
+)
+
+// importColumns
+// Columns read from the CSV header. Others, such as id and created_date of an export, are ignored.
+var importColumns = []string{"username", "provider_id", "service_id", "rate", "title", "comment"}
+
+type importLine struct {
+	number int
+	model  rating.SendRatingServiceModel
+}
+
+type importSummary struct {
+	read     int
+	inserted int
+	valid    int
+	rejected int
+}
+
+// Import
+// Runs "rating-api import --file ratings.csv [--dry-run]". Rows are validated like POST /v1/rating/add
+// and loaded in batches of RATING_BULK_MAX_ITEMS, every rejected line is reported to stdout.
+// A ServiceId repeated anywhere in the file or already rated is rejected, on a dry run as well.
+// Returns 0 when every row was loaded (or is valid on a dry run), 1 when some rows were rejected
+// and 2 on usage or fatal errors.
+func Import(args []string, environment env.IEnvironment, ratingService rating.IRatingService, stdout io.Writer) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	file := flags.String("file", "", "CSV file with a header row of "+strings.Join(importColumns, ","))
+	dryRun := flags.Bool("dry-run", false, "validate rows without loading them")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" {
+		fmt.Fprintln(stdout, "import: --file is required")
+		flags.Usage()
+		return 2
+	}
+
+	input, err := os.Open(*file)
+	if err != nil {
+		fmt.Fprintln(stdout, "import: "+err.Error())
+		return 2
+	}
+	defer input.Close()
+
+	reader := csv.NewReader(input)
+	reader.FieldsPerRecord = -1
+	columns, err := readImportHeader(reader)
+	if err != nil {
+		fmt.Fprintln(stdout, "import: "+err.Error())
+		return 2
+	}
+
+	batchSize := environment.GetInt(env.RatingBulkMaxItems, 500)
+	batch := make([]importLine, 0, batchSize)
+	var summary importSummary
+	seen := make(map[string]int)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				fmt.Fprintln(stdout, "import: "+err.Error())
+				return 2
+			}
+			summary.read++
+			summary.rejected++
+			fmt.Fprintf(stdout, "line %d: %s\n", parseErr.Line, parseErr.Err)
+			continue
+		}
+
+		summary.read++
+		model, err := parseImportRecord(record, columns)
+		if err != nil {
+			summary.rejected++
+			fmt.Fprintf(stdout, "line %d: %s\n", line, err)
+			continue
+		}
+
+		if first, ok := seen[model.ServiceId]; ok && model.ServiceId != "" {
+			summary.rejected++
+			fmt.Fprintf(stdout, "line %d: ServiceId %q %s: Duplicate ServiceId in file, first on line %d\n",
+				line, model.ServiceId, rating.BulkStatusDuplicate, first)
+			continue
+		}
+		seen[model.ServiceId] = line
+
+		batch = append(batch, importLine{number: line, model: *model})
+		if len(batch) == batchSize {
+			if err := sendImportBatch(ratingService, batch, *dryRun, &summary, stdout); err != nil {
+				fmt.Fprintln(stdout, "import: "+err.Error())
+				return 2
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := sendImportBatch(ratingService, batch, *dryRun, &summary, stdout); err != nil {
+			fmt.Fprintln(stdout, "import: "+err.Error())
+			return 2
+		}
+	}
+
+	if *dryRun {
+		fmt.Fprintf(stdout, "dry run: read %d rows, %d valid, %d rejected\n", summary.read, summary.valid, summary.rejected)
+	} else {
+		fmt.Fprintf(stdout, "read %d rows, inserted %d, rejected %d\n", summary.read, summary.inserted, summary.rejected)
+	}
+
+	if summary.rejected > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// readImportHeader
+// Returns the position of every import column in the header row.
+func readImportHeader(reader *csv.Reader) (map[string]int, error) {
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read header row: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, required := range importColumns[:4] {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("header row is missing column %q", required)
+		}
+	}
+
+	return columns, nil
+}
+
+func parseImportRecord(record []string, columns map[string]int) (*rating.SendRatingServiceModel, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	rate, err := strconv.Atoi(field("rate"))
+	if err != nil {
+		return nil, fmt.Errorf("rate %q is not a number", field("rate"))
+	}
+
+	return &rating.SendRatingServiceModel{
+		UserName:   unescapeCsvFormula(field("username")),
+		ProviderId: unescapeCsvFormula(field("provider_id")),
+		ServiceId:  unescapeCsvFormula(field("service_id")),
+		Rate:       rate,
+		Title:      unescapeCsvFormula(field("title")),
+		Comment:    unescapeCsvFormula(field("comment")),
+	}, nil
+}
+
+// unescapeCsvFormula
+// Removes the quote the CSV export puts in front of text starting like a spreadsheet formula, only from text that
+// starts with quotes followed by one of =, +, -, @, tab or carriage return. Any other quote is part of the text.
+func unescapeCsvFormula(value string) string {
+	unquoted := strings.TrimLeft(value, "'")
+	if len(unquoted) < len(value) && unquoted != "" && strings.ContainsRune("=+-@\t\r", rune(unquoted[0])) {
+		return value[1:]
+	}
+
+	return value
+}
+
+func sendImportBatch(ratingService rating.IRatingService, batch []importLine, dryRun bool, summary *importSummary, stdout io.Writer) error {
+	model := rating.SendRatingsBulkServiceModel{
+		Ratings: make([]rating.SendRatingServiceModel, 0, len(batch)),
+		DryRun:  dryRun,
+	}
+	for _, line := range batch {
+		model.Ratings = append(model.Ratings, line.model)
+	}
+
+	response, err := ratingService.SendRatingsBulk(context.Background(), &model)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range response.Results {
+		switch result.Status {
+		case rating.BulkStatusInserted:
+			summary.inserted++
+		case rating.BulkStatusValid:
+			summary.valid++
+		default:
+			summary.rejected++
+			fmt.Fprintf(stdout, "line %d: ServiceId %q %s: %s\n", batch[result.Index].number, result.ServiceId, result.Status, result.Error)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"rating-api/internal/service/rating"
+	"rating-api/internal/util/env"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImportTestSuite struct {
+	suite.Suite
+	mockEnvironment   *env.MockIEnvironment
+	mockRatingService *rating.MockIRatingService
+}
+
+// Run suite.
+func TestImport(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
+
+// Runs before each test in the suite.
+func (i *ImportTestSuite) SetupTest() {
+	ctrl := gomock.NewController(i.T())
+	i.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	i.mockRatingService = rating.NewMockIRatingService(ctrl)
+}
+
+func (i *ImportTestSuite) writeFile(content string) string {
+	file := filepath.Join(i.T().TempDir(), "ratings.csv")
+	i.Require().Nil(os.WriteFile(file, []byte(content), 0600))
+	return file
+}
+
+func (i *ImportTestSuite) TestImport_ExportedFormulaEscapes_AreRemoved() {
+	file := i.writeFile("username,provider_id,service_id,rate,title,comment\n" +
+		"'@u-1,'=p-1,s-1,5,\"'=HYPERLINK(\"\"x\"\")\",'-1+1\n" +
+		"u-2,p-1,'+s-2,4,''-) great,'''=x\n")
+
+	i.mockEnvironment.EXPECT().GetInt(env.RatingBulkMaxItems, gomock.Any()).Return(500)
+	i.mockRatingService.
+		EXPECT().
+		SendRatingsBulk(gomock.Any(), gomock.Eq(&rating.SendRatingsBulkServiceModel{Ratings: []rating.SendRatingServiceModel{
+			{UserName: "@u-1", ProviderId: "=p-1", ServiceId: "s-1", Rate: 5, Title: "=HYPERLINK(\"x\")", Comment: "-1+1"},
+			{UserName: "u-2", ProviderId: "p-1", ServiceId: "+s-2", Rate: 4, Title: "'-) great", Comment: "''=x"},
+		}})).
+		Return(&rating.SendRatingsBulkServiceResponse{Inserted: 2, Results: []rating.BulkRatingResultModel{
+			{Index: 0, ServiceId: "s-1", Status: rating.BulkStatusInserted},
+			{Index: 1, ServiceId: "+s-2", Status: rating.BulkStatusInserted},
+		}}, nil)
+
+	var stdout bytes.Buffer
+	code := Import([]string{"--file", file}, i.mockEnvironment, i.mockRatingService, &stdout)
+
+	i.Equal(0, code)
+	i.Equal("read 2 rows, inserted 2, rejected 0\n", stdout.String())
+}
+
+func (i *ImportTestSuite) TestImport_QuoteThatIsNoEscape_IsKept() {
+	file := i.writeFile("username,provider_id,service_id,rate,title,comment\n" +
+		"'u-1',p-1,s-1,5,'great',it's fine\n" +
+		"u-2,p-1,s-2,4,',''\n")
+
+	i.mockEnvironment.EXPECT().GetInt(env.RatingBulkMaxItems, gomock.Any()).Return(500)
+	i.mockRatingService.
+		EXPECT().
+		SendRatingsBulk(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, model *rating.SendRatingsBulkServiceModel) (*rating.SendRatingsBulkServiceResponse, error) {
+			i.Equal([]rating.SendRatingServiceModel{
+				{UserName: "'u-1'", ProviderId: "p-1", ServiceId: "s-1", Rate: 5, Title: "'great'", Comment: "it's fine"},
+				{UserName: "u-2", ProviderId: "p-1", ServiceId: "s-2", Rate: 4, Title: "'", Comment: "''"},
+			}, model.Ratings)
+
+			return &rating.SendRatingsBulkServiceResponse{Inserted: 2, Results: []rating.BulkRatingResultModel{
+				{Index: 0, ServiceId: "s-1", Status: rating.BulkStatusInserted},
+				{Index: 1, ServiceId: "s-2", Status: rating.BulkStatusInserted},
+			}}, nil
+		})
+
+	var stdout bytes.Buffer
+	code := Import([]string{"--file", file}, i.mockEnvironment, i.mockRatingService, &stdout)
+
+	i.Equal(0, code)
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"rating-api/internal/data/database/migration"
+	"time"
+)
+
+// Migrate
+// Runs "rating-api migrate up|down [--steps n]|status" against the migrations embedded in the binary.
+// Returns 0 on success, 1 when a migration failed and 2 on usage errors.
+func Migrate(args []string, migrationDb migration.IMigrationDb, stdout io.Writer) int {
+	if len(args) < 1 {
+		fmt.Fprintln(stdout, "usage: rating-api migrate up|down [--steps n]|status")
+		return 2
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		response, err := migrationDb.Up(ctx)
+		if err != nil {
+			fmt.Fprintln(stdout, "migrate: "+err.Error())
+			return 1
+		}
+		if len(response.Migrations) == 0 {
+			fmt.Fprintln(stdout, "no pending migrations")
+		}
+		for _, status := range response.Migrations {
+			fmt.Fprintf(stdout, "applied %d_%s\n", status.Version, status.Name)
+		}
+	case "down":
+		flags := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+		flags.SetOutput(stdout)
+		steps := flags.Int("steps", 1, "number of migrations to revert")
+		if err := flags.Parse(args[1:]); err != nil {
+			return 2
+		}
+
+		response, err := migrationDb.Down(ctx, *steps)
+		if err != nil {
+			fmt.Fprintln(stdout, "migrate: "+err.Error())
+			return 1
+		}
+		if len(response.Migrations) == 0 {
+			fmt.Fprintln(stdout, "no applied migrations")
+		}
+		for _, status := range response.Migrations {
+			fmt.Fprintf(stdout, "reverted %d_%s\n", status.Version, status.Name)
+		}
+	case "status":
+		response, err := migrationDb.Status(ctx)
+		if err != nil {
+			fmt.Fprintln(stdout, "migrate: "+err.Error())
+			return 1
+		}
+		for _, status := range response.Migrations {
+			appliedDate := "pending"
+			if status.AppliedDate != nil {
+				appliedDate = "applied " + status.AppliedDate.Format(time.RFC3339)
+			}
+			fmt.Fprintf(stdout, "%d_%s\t%s\n", status.Version, status.Name, appliedDate)
+		}
+	default:
+		fmt.Fprintln(stdout, "usage: rating-api migrate up|down [--steps n]|status")
+		return 2
+	}
+
+	return 0
+}
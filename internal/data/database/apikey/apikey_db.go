@@ -0,0 +1,240 @@
+package apikey
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"rating-api/internal/data/database"
+	"rating-api/internal/util/env"
+	"rating-api/internal/util/logger"
+	"rating-api/internal/util/validator"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type IApiKeyDb interface {
+	AddKey(ctx context.Context, model *AddKeyModel) (*AddKeyResponse, error)
+	GetKey(ctx context.Context, model *GetKeyModel) (*GetKeyResponse, error)
+	TouchKey(ctx context.Context, model *TouchKeyModel) (*TouchKeyResponse, error)
+	RevokeKey(ctx context.Context, model *RevokeKeyModel) (*RevokeKeyResponse, error)
+	ListKeys(ctx context.Context, model *ListKeysModel) (*ListKeysResponse, error)
+	Close() error
+}
+
+var ErrKeyNotFound = errors.New("api key not found")
+
+// touchInterval
+// last_used_date is written at most this often per key, so busy clients do not update the row on every request.
+const touchInterval = time.Minute
+
+type ApiKeyDb struct {
+	loggr          logger.ILogger
+	validatr       validator.IValidator
+	environment    env.IEnvironment
+	timeout        time.Duration
+	connection     *sql.DB
+	ownsConnection bool
+}
+
+// NewApiKeyDb
+// Returns a new ApiKeyDb on the shared connection pool, or on a pool of its own when connection is nil.
+func NewApiKeyDb(loggr logger.ILogger, validatr validator.IValidator, environment env.IEnvironment, connection *sql.DB) IApiKeyDb {
+	db := ApiKeyDb{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		timeout:     time.Second * 5,
+		connection:  connection,
+	}
+
+	if connection == nil {
+		db.connection = database.Open(loggr, environment)
+		db.ownsConnection = true
+	}
+
+	return &db
+}
+
+// Close
+// Closes the connection pool if ApiKeyDb opened it itself. A shared pool is closed by its owner.
+func (d *ApiKeyDb) Close() error {
+	if !d.ownsConnection {
+		return nil
+	}
+
+	return d.connection.Close()
+}
+
+// AddKey
+// Stores a new key by the hash of its secret.
+func (d *ApiKeyDb) AddKey(ctx context.Context, model *AddKeyModel) (*AddKeyResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `insert into api_keys (name, prefix, key_hash, scopes, created_date)
+				values ($1, $2, $3, $4, current_timestamp)
+				returning id`
+
+	var response AddKeyResponse
+	dbErr := d.connection.QueryRowContext(ctx, query, model.Name, model.Prefix, model.KeyHash, pq.Array(model.Scopes)).Scan(&response.Id)
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+
+	return &response, nil
+}
+
+// GetKey
+// Returns the key with the given hash, or ErrKeyNotFound when there is none or it was revoked.
+func (d *ApiKeyDb) GetKey(ctx context.Context, model *GetKeyModel) (*GetKeyResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `select ` + apiKeyColumns + `
+				from api_keys
+				where key_hash = $1 and revoked_date is null`
+
+	record, dbErr := scanApiKey(d.connection.QueryRowContext(ctx, query, model.KeyHash).Scan)
+	if dbErr == sql.ErrNoRows {
+		return nil, ErrKeyNotFound
+	}
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+
+	return &GetKeyResponse{Key: *record}, nil
+}
+
+// TouchKey
+// Records that the key was used, unless that was already recorded within the last minute.
+func (d *ApiKeyDb) TouchKey(ctx context.Context, model *TouchKeyModel) (*TouchKeyResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `update api_keys
+				set last_used_date = current_timestamp
+				where id = $1 and (last_used_date is null or last_used_date < current_timestamp - make_interval(secs => $2))`
+
+	result, dbErr := d.connection.ExecContext(ctx, query, model.Id, touchInterval.Seconds())
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+
+	touched, dbErr := result.RowsAffected()
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+
+	return &TouchKeyResponse{Touched: touched == 1}, nil
+}
+
+// RevokeKey
+// Revokes the key, or returns ErrKeyNotFound when it does not exist or is already revoked.
+func (d *ApiKeyDb) RevokeKey(ctx context.Context, model *RevokeKeyModel) (*RevokeKeyResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `update api_keys
+				set revoked_date = current_timestamp
+				where id = $1 and revoked_date is null
+				returning id`
+
+	var response RevokeKeyResponse
+	dbErr := d.connection.QueryRowContext(ctx, query, model.Id).Scan(&response.Id)
+	if dbErr == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w for Id: %d", ErrKeyNotFound, model.Id)
+	}
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+
+	return &response, nil
+}
+
+// ListKeys
+// Returns the keys ordered by id, without revoked ones unless asked for.
+func (d *ApiKeyDb) ListKeys(ctx context.Context, model *ListKeysModel) (*ListKeysResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `select ` + apiKeyColumns + `
+				from api_keys
+				where $1 or revoked_date is null
+				order by id`
+
+	rows, dbErr := d.connection.QueryContext(ctx, query, model.IncludeRevoked)
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+	defer rows.Close()
+
+	var response ListKeysResponse
+	for rows.Next() {
+		record, err := scanApiKey(rows.Scan)
+		if err != nil {
+			d.loggr.Error(err.Error())
+			return nil, err
+		}
+		response.Keys = append(response.Keys, *record)
+	}
+	if err := rows.Err(); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+const apiKeyColumns = `id, name, prefix, scopes, created_date, last_used_date, revoked_date`
+
+// scanApiKey
+// Reads apiKeyColumns with the Scan method of a row or rows.
+func scanApiKey(scan func(dest ...any) error) (*ApiKeyRecord, error) {
+	var record ApiKeyRecord
+	var lastUsedDate, revokedDate sql.NullTime
+	err := scan(&record.Id, &record.Name, &record.Prefix, pq.Array(&record.Scopes), &record.CreatedDate, &lastUsedDate, &revokedDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastUsedDate.Valid {
+		record.LastUsedDate = &lastUsedDate.Time
+	}
+	if revokedDate.Valid {
+		record.RevokedDate = &revokedDate.Time
+	}
+
+	return &record, nil
+}
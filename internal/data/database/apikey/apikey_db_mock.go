@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/database/apikey/apikey_db.go
+
+// Package apikey is a generated GoMock package.
+package apikey
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIApiKeyDb is a mock of IApiKeyDb interface.
+type MockIApiKeyDb struct {
+	ctrl     *gomock.Controller
+	recorder *MockIApiKeyDbMockRecorder
+}
+
+// MockIApiKeyDbMockRecorder is the mock recorder for MockIApiKeyDb.
+type MockIApiKeyDbMockRecorder struct {
+	mock *MockIApiKeyDb
+}
+
+// NewMockIApiKeyDb creates a new mock instance.
+func NewMockIApiKeyDb(ctrl *gomock.Controller) *MockIApiKeyDb {
+	mock := &MockIApiKeyDb{ctrl: ctrl}
+	mock.recorder = &MockIApiKeyDbMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIApiKeyDb) EXPECT() *MockIApiKeyDbMockRecorder {
+	return m.recorder
+}
+
+// AddKey mocks base method.
+func (m *MockIApiKeyDb) AddKey(ctx context.Context, model *AddKeyModel) (*AddKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddKey", ctx, model)
+	ret0, _ := ret[0].(*AddKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddKey indicates an expected call of AddKey.
+func (mr *MockIApiKeyDbMockRecorder) AddKey(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddKey", reflect.TypeOf((*MockIApiKeyDb)(nil).AddKey), ctx, model)
+}
+
+// Close mocks base method.
+func (m *MockIApiKeyDb) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIApiKeyDbMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIApiKeyDb)(nil).Close))
+}
+
+// GetKey mocks base method.
+func (m *MockIApiKeyDb) GetKey(ctx context.Context, model *GetKeyModel) (*GetKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKey", ctx, model)
+	ret0, _ := ret[0].(*GetKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKey indicates an expected call of GetKey.
+func (mr *MockIApiKeyDbMockRecorder) GetKey(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKey", reflect.TypeOf((*MockIApiKeyDb)(nil).GetKey), ctx, model)
+}
+
+// ListKeys mocks base method.
+func (m *MockIApiKeyDb) ListKeys(ctx context.Context, model *ListKeysModel) (*ListKeysResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKeys", ctx, model)
+	ret0, _ := ret[0].(*ListKeysResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKeys indicates an expected call of ListKeys.
+func (mr *MockIApiKeyDbMockRecorder) ListKeys(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeys", reflect.TypeOf((*MockIApiKeyDb)(nil).ListKeys), ctx, model)
+}
+
+// RevokeKey mocks base method.
+func (m *MockIApiKeyDb) RevokeKey(ctx context.Context, model *RevokeKeyModel) (*RevokeKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeKey", ctx, model)
+	ret0, _ := ret[0].(*RevokeKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeKey indicates an expected call of RevokeKey.
+func (mr *MockIApiKeyDbMockRecorder) RevokeKey(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeKey", reflect.TypeOf((*MockIApiKeyDb)(nil).RevokeKey), ctx, model)
+}
+
+// TouchKey mocks base method.
+func (m *MockIApiKeyDb) TouchKey(ctx context.Context, model *TouchKeyModel) (*TouchKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchKey", ctx, model)
+	ret0, _ := ret[0].(*TouchKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TouchKey indicates an expected call of TouchKey.
+func (mr *MockIApiKeyDbMockRecorder) TouchKey(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchKey", reflect.TypeOf((*MockIApiKeyDb)(nil).TouchKey), ctx, model)
+}
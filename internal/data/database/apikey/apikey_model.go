@@ -0,0 +1,24 @@
+package apikey
+
+type AddKeyModel struct {
+	Name    string   `validate:"required,max=100"`
+	Prefix  string   `validate:"required,max=16"`
+	KeyHash string   `validate:"required,len=64"`
+	Scopes  []string `validate:"required,min=1"`
+}
+
+type GetKeyModel struct {
+	KeyHash string `validate:"required,len=64"`
+}
+
+type TouchKeyModel struct {
+	Id int64 `validate:"required"`
+}
+
+type RevokeKeyModel struct {
+	Id int64 `validate:"required"`
+}
+
+type ListKeysModel struct {
+	IncludeRevoked bool
+}
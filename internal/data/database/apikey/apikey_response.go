@@ -0,0 +1,33 @@
+package apikey
+
+import "time"
+
+type ApiKeyRecord struct {
+	Id           int64
+	Name         string
+	Prefix       string
+	Scopes       []string
+	CreatedDate  time.Time
+	LastUsedDate *time.Time
+	RevokedDate  *time.Time
+}
+
+type AddKeyResponse struct {
+	Id int64
+}
+
+type GetKeyResponse struct {
+	Key ApiKeyRecord
+}
+
+type TouchKeyResponse struct {
+	Touched bool
+}
+
+type RevokeKeyResponse struct {
+	Id int64
+}
+
+type ListKeysResponse struct {
+	Keys []ApiKeyRecord
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"database/sql"
+	"rating-api/internal/util/env"
+	"rating-api/internal/util/logger"
+	"rating-api/internal/util/metrics"
+	"time"
+)
+
+// Open
+// Opens the connection pool shared by every database type of the process and exports its stats as metrics.
+// Should be called once on startup; the caller closes the pool on shutdown.
+func Open(loggr logger.ILogger, environment env.IEnvironment) *sql.DB {
+	connection, err := sql.Open("postgres", environment.Get(env.PostgresqlConnectionString))
+	if err != nil {
+		loggr.Error(err.Error())
+		panic("Panicked while opening database connection pool.")
+	}
+
+	connection.SetMaxOpenConns(environment.GetInt(env.PostgresqlMaxOpenConns, 25))
+	connection.SetMaxIdleConns(environment.GetInt(env.PostgresqlMaxIdleConns, 10))
+	connection.SetConnMaxLifetime(environment.GetDuration(env.PostgresqlConnMaxLifetime, 30*time.Minute))
+	connection.SetConnMaxIdleTime(environment.GetDuration(env.PostgresqlConnMaxIdleTime, 5*time.Minute))
+
+	metrics.RegisterPool("postgres", connection)
+
+	return connection
+}
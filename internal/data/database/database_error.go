@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/lib/pq"
+)
+
+// IsUnavailable
+// Reports whether err means the database could not be reached or could not serve the query in time,
+// as opposed to a failure of the query itself.
+func IsUnavailable(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code.Class() {
+		// connection_exception, insufficient_resources, operator_intervention
+		case "08", "53", "57":
+			return true
+		}
+	}
+
+	return false
+}
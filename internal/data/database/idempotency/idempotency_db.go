@@ -0,0 +1,193 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"rating-api/internal/data/database"
+	"rating-api/internal/util/env"
+	"rating-api/internal/util/logger"
+	"rating-api/internal/util/validator"
+	"time"
+)
+
+type IIdempotencyDb interface {
+	Reserve(ctx context.Context, model *ReserveKeyModel) (*ReserveKeyResponse, error)
+	Complete(ctx context.Context, model *CompleteKeyModel) (*CompleteKeyResponse, error)
+	Release(ctx context.Context, model *ReleaseKeyModel) (*ReleaseKeyResponse, error)
+	Close() error
+}
+
+type IdempotencyDb struct {
+	loggr          logger.ILogger
+	validatr       validator.IValidator
+	environment    env.IEnvironment
+	timeout        time.Duration
+	connection     *sql.DB
+	ownsConnection bool
+}
+
+// NewIdempotencyDb
+// Returns a new IdempotencyDb on the shared connection pool, or on a pool of its own when connection is nil.
+func NewIdempotencyDb(loggr logger.ILogger, validatr validator.IValidator, environment env.IEnvironment, connection *sql.DB) IIdempotencyDb {
+	db := IdempotencyDb{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		timeout:     time.Second * 5,
+		connection:  connection,
+	}
+
+	if connection == nil {
+		db.connection = database.Open(loggr, environment)
+		db.ownsConnection = true
+	}
+
+	return &db
+}
+
+// Close
+// Closes the connection pool if IdempotencyDb opened it itself. A shared pool is closed by its owner.
+func (d *IdempotencyDb) Close() error {
+	if !d.ownsConnection {
+		return nil
+	}
+
+	return d.connection.Close()
+}
+
+// Reserve
+// Reserves the key of the subject for a new request for the Lease duration. A row older than the TTL, or a reservation without
+// a response whose lease ran out because its request crashed, is discarded first so the key can be reused.
+// When the key is already taken the existing record is returned instead.
+func (d *IdempotencyDb) Reserve(ctx context.Context, model *ReserveKeyModel) (*ReserveKeyResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	tx, err := d.connection.BeginTx(ctx, nil)
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `delete from idempotency_keys
+				where scope = $1 and subject = $2 and key = $3
+				and (created_date < current_timestamp - make_interval(secs => $4)
+					or (status_code is null and coalesce(locked_until, created_date) < current_timestamp))`
+	if _, err := tx.ExecContext(ctx, query, model.Scope, model.Subject, model.Key, model.Ttl.Seconds()); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	query = `insert into idempotency_keys (scope, subject, key, request_hash, created_date, locked_until)
+				values ($1, $2, $3, $4, current_timestamp, current_timestamp + make_interval(secs => $5))
+				on conflict (scope, subject, key)
+				do nothing`
+	result, err := tx.ExecContext(ctx, query, model.Scope, model.Subject, model.Key, model.RequestHash, model.Lease.Seconds())
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	var response ReserveKeyResponse
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	if inserted == 1 {
+		response.Reserved = true
+	} else {
+		var record IdempotencyRecord
+		var statusCode sql.NullInt32
+		var contentType sql.NullString
+		query = `select request_hash, status_code, content_type, response_body
+					from idempotency_keys
+					where scope = $1 and subject = $2 and key = $3`
+		err = tx.QueryRowContext(ctx, query, model.Scope, model.Subject, model.Key).Scan(&record.RequestHash, &statusCode, &contentType, &record.Body)
+		if err != nil {
+			d.loggr.Error(err.Error())
+			return nil, err
+		}
+
+		if statusCode.Valid {
+			code := int(statusCode.Int32)
+			record.StatusCode = &code
+		}
+		record.ContentType = contentType.String
+		response.Record = &record
+	}
+
+	if err := tx.Commit(); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Complete
+// Stores the response of the request holding the key.
+func (d *IdempotencyDb) Complete(ctx context.Context, model *CompleteKeyModel) (*CompleteKeyResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `update idempotency_keys
+				set status_code = $4, content_type = $5, response_body = $6
+				where scope = $1 and subject = $2 and key = $3 and status_code is null`
+	result, err := d.connection.ExecContext(ctx, query, model.Scope, model.Subject, model.Key, model.StatusCode, model.ContentType, model.Body)
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &CompleteKeyResponse{Completed: updated == 1}, nil
+}
+
+// Release
+// Drops a key that has no stored response yet, so the request can be retried with it.
+func (d *IdempotencyDb) Release(ctx context.Context, model *ReleaseKeyModel) (*ReleaseKeyResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `delete from idempotency_keys
+				where scope = $1 and subject = $2 and key = $3 and status_code is null`
+	result, err := d.connection.ExecContext(ctx, query, model.Scope, model.Subject, model.Key)
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &ReleaseKeyResponse{Released: deleted == 1}, nil
+}
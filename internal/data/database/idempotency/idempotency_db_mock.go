@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/database/idempotency/idempotency_db.go
+
+// Package idempotency is a generated GoMock package.
+package idempotency
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIIdempotencyDb is a mock of IIdempotencyDb interface.
+type MockIIdempotencyDb struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIdempotencyDbMockRecorder
+}
+
+// MockIIdempotencyDbMockRecorder is the mock recorder for MockIIdempotencyDb.
+type MockIIdempotencyDbMockRecorder struct {
+	mock *MockIIdempotencyDb
+}
+
+// NewMockIIdempotencyDb creates a new mock instance.
+func NewMockIIdempotencyDb(ctrl *gomock.Controller) *MockIIdempotencyDb {
+	mock := &MockIIdempotencyDb{ctrl: ctrl}
+	mock.recorder = &MockIIdempotencyDbMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIdempotencyDb) EXPECT() *MockIIdempotencyDbMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockIIdempotencyDb) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIIdempotencyDbMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIIdempotencyDb)(nil).Close))
+}
+
+// Complete mocks base method.
+func (m *MockIIdempotencyDb) Complete(ctx context.Context, model *CompleteKeyModel) (*CompleteKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, model)
+	ret0, _ := ret[0].(*CompleteKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockIIdempotencyDbMockRecorder) Complete(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockIIdempotencyDb)(nil).Complete), ctx, model)
+}
+
+// Release mocks base method.
+func (m *MockIIdempotencyDb) Release(ctx context.Context, model *ReleaseKeyModel) (*ReleaseKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, model)
+	ret0, _ := ret[0].(*ReleaseKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockIIdempotencyDbMockRecorder) Release(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockIIdempotencyDb)(nil).Release), ctx, model)
+}
+
+// Reserve mocks base method.
+func (m *MockIIdempotencyDb) Reserve(ctx context.Context, model *ReserveKeyModel) (*ReserveKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, model)
+	ret0, _ := ret[0].(*ReserveKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockIIdempotencyDbMockRecorder) Reserve(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockIIdempotencyDb)(nil).Reserve), ctx, model)
+}
@@ -0,0 +1,27 @@
+package idempotency
+
+import "time"
+
+type ReserveKeyModel struct {
+	Scope       string        `validate:"required,max=64"`
+	Subject     string        `validate:"max=255"`
+	Key         string        `validate:"required,max=255"`
+	RequestHash string        `validate:"required,len=64"`
+	Ttl         time.Duration `validate:"gt=0"`
+	Lease       time.Duration `validate:"gt=0"`
+}
+
+type CompleteKeyModel struct {
+	Scope       string `validate:"required,max=64"`
+	Subject     string `validate:"max=255"`
+	Key         string `validate:"required,max=255"`
+	StatusCode  int    `validate:"gte=100,lte=599"`
+	ContentType string `validate:"max=255"`
+	Body        []byte
+}
+
+type ReleaseKeyModel struct {
+	Scope   string `validate:"required,max=64"`
+	Subject string `validate:"max=255"`
+	Key     string `validate:"required,max=255"`
+}
@@ -0,0 +1,23 @@
+package idempotency
+
+// IdempotencyRecord
+// A stored key. StatusCode is nil while the first request holding the key is still running.
+type IdempotencyRecord struct {
+	RequestHash string
+	StatusCode  *int
+	ContentType string
+	Body        []byte
+}
+
+type ReserveKeyResponse struct {
+	Reserved bool
+	Record   *IdempotencyRecord
+}
+
+type CompleteKeyResponse struct {
+	Completed bool
+}
+
+type ReleaseKeyResponse struct {
+	Released bool
+}
@@ -0,0 +1,265 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"rating-api/internal/util/env"
+	"rating-api/internal/util/logger"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type IMigrationDb interface {
+	Up(ctx context.Context) (*MigrateResponse, error)
+	Down(ctx context.Context, steps int) (*MigrateResponse, error)
+	Status(ctx context.Context) (*GetMigrationStatusResponse, error)
+	Close() error
+}
+
+// migrationLockId
+// Key of the postgres advisory lock held while migrating, so that instances starting together migrate one at a time.
+const migrationLockId int64 = 7239118421
+
+type MigrationDb struct {
+	loggr            logger.ILogger
+	environment      env.IEnvironment
+	connectionString string
+	driverName       string
+	connection       *sql.DB
+	migrations       []Migration
+}
+
+// NewMigrationDb
+// Returns a new MigrationDb applying the migrations embedded in the binary.
+func NewMigrationDb(loggr logger.ILogger, environment env.IEnvironment) IMigrationDb {
+	db := MigrationDb{
+		environment:      environment,
+		loggr:            loggr,
+		driverName:       "postgres",
+		connectionString: environment.Get(env.PostgresqlConnectionString),
+	}
+
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		loggr.Error(err.Error())
+		panic("Panicked while loading migrations.")
+	}
+
+	connection, err := sql.Open(db.driverName, db.connectionString)
+	if err != nil {
+		loggr.Error(err.Error())
+		panic("Panicked while opening database connection pool.")
+	}
+	connection.SetMaxOpenConns(1)
+
+	db.connection = connection
+	db.migrations = migrations
+
+	return &db
+}
+
+// Close
+// Closes the connection pool.
+func (d *MigrationDb) Close() error {
+	return d.connection.Close()
+}
+
+// Up
+// Applies every pending migration in version order, each one in its own transaction.
+func (d *MigrationDb) Up(ctx context.Context) (*MigrateResponse, error) {
+	var response MigrateResponse
+
+	err := d.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := d.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range d.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			query := `insert into schema_migrations (version, name, applied_date) values ($1, $2, current_timestamp)`
+			if err := d.apply(ctx, conn, migration, migration.Up, query, migration.Version, migration.Name); err != nil {
+				return err
+			}
+
+			d.loggr.Info("Applied migration", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+			response.Migrations = append(response.Migrations, MigrationStatus{Version: migration.Version, Name: migration.Name, Applied: true})
+		}
+
+		return nil
+	})
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Down
+// Reverts the latest steps applied migrations, newest first.
+func (d *MigrationDb) Down(ctx context.Context, steps int) (*MigrateResponse, error) {
+	if steps < 1 {
+		err := fmt.Errorf("steps must be at least 1")
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	var response MigrateResponse
+
+	err := d.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := d.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(d.migrations) - 1; i >= 0 && len(response.Migrations) < steps; i-- {
+			migration := d.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			query := `delete from schema_migrations where version = $1`
+			if err := d.apply(ctx, conn, migration, migration.Down, query, migration.Version); err != nil {
+				return err
+			}
+
+			d.loggr.Info("Reverted migration", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+			response.Migrations = append(response.Migrations, MigrationStatus{Version: migration.Version, Name: migration.Name})
+		}
+
+		return nil
+	})
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Status
+// Returns every known migration with the date it was applied. Versions applied by a newer binary are listed too.
+func (d *MigrationDb) Status(ctx context.Context) (*GetMigrationStatusResponse, error) {
+	conn, err := d.connection.Conn(ctx)
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+	defer conn.Close()
+
+	applied, err := d.appliedVersions(ctx, conn)
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	var response GetMigrationStatusResponse
+	for _, migration := range d.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedDate = &row.appliedDate
+			delete(applied, migration.Version)
+		}
+		response.Migrations = append(response.Migrations, status)
+	}
+
+	for version, row := range applied {
+		appliedDate := row.appliedDate
+		response.Migrations = append(response.Migrations, MigrationStatus{Version: version, Name: row.name, Applied: true, AppliedDate: &appliedDate})
+	}
+
+	sort.Slice(response.Migrations, func(i, j int) bool {
+		return response.Migrations[i].Version < response.Migrations[j].Version
+	})
+
+	return &response, nil
+}
+
+// locked
+// Runs fn on a single connection holding the migration advisory lock, creating schema_migrations if needed.
+func (d *MigrationDb) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := d.connection.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `select pg_advisory_lock($1)`, migrationLockId); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), `select pg_advisory_unlock($1)`, migrationLockId)
+
+	query := `create table if not exists schema_migrations
+				(
+					version      bigint       not null constraint schema_migrations_pk primary key,
+					name         varchar(255) not null,
+					applied_date timestamp    not null
+				)`
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// apply
+// Runs script and the schema_migrations bookkeeping query in one transaction.
+func (d *MigrationDb) apply(ctx context.Context, conn *sql.Conn, migration Migration, script string, query string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+type appliedMigration struct {
+	name        string
+	appliedDate time.Time
+}
+
+func (d *MigrationDb) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	applied := make(map[int64]appliedMigration)
+
+	var exists bool
+	if err := conn.QueryRowContext(ctx, `select to_regclass('schema_migrations') is not null`).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return applied, nil
+	}
+
+	rows, err := conn.QueryContext(ctx, `select version, name, applied_date from schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int64
+		var row appliedMigration
+		if err := rows.Scan(&version, &row.name, &row.appliedDate); err != nil {
+			return nil, err
+		}
+		applied[version] = row
+	}
+
+	return applied, rows.Err()
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/database/migration/migration_db.go
+
+// Package migration is a generated GoMock package.
+package migration
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIMigrationDb is a mock of IMigrationDb interface.
+type MockIMigrationDb struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMigrationDbMockRecorder
+}
+
+// MockIMigrationDbMockRecorder is the mock recorder for MockIMigrationDb.
+type MockIMigrationDbMockRecorder struct {
+	mock *MockIMigrationDb
+}
+
+// NewMockIMigrationDb creates a new mock instance.
+func NewMockIMigrationDb(ctrl *gomock.Controller) *MockIMigrationDb {
+	mock := &MockIMigrationDb{ctrl: ctrl}
+	mock.recorder = &MockIMigrationDbMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMigrationDb) EXPECT() *MockIMigrationDbMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockIMigrationDb) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIMigrationDbMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIMigrationDb)(nil).Close))
+}
+
+// Down mocks base method.
+func (m *MockIMigrationDb) Down(ctx context.Context, steps int) (*MigrateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Down", ctx, steps)
+	ret0, _ := ret[0].(*MigrateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Down indicates an expected call of Down.
+func (mr *MockIMigrationDbMockRecorder) Down(ctx, steps interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Down", reflect.TypeOf((*MockIMigrationDb)(nil).Down), ctx, steps)
+}
+
+// Status mocks base method.
+func (m *MockIMigrationDb) Status(ctx context.Context) (*GetMigrationStatusResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", ctx)
+	ret0, _ := ret[0].(*GetMigrationStatusResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockIMigrationDbMockRecorder) Status(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockIMigrationDb)(nil).Status), ctx)
+}
+
+// Up mocks base method.
+func (m *MockIMigrationDb) Up(ctx context.Context) (*MigrateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Up", ctx)
+	ret0, _ := ret[0].(*MigrateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Up indicates an expected call of Up.
+func (mr *MockIMigrationDbMockRecorder) Up(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Up", reflect.TypeOf((*MockIMigrationDb)(nil).Up), ctx)
+}
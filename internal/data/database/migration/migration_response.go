@@ -0,0 +1,18 @@
+package migration
+
+import "time"
+
+type MigrationStatus struct {
+	Version     int64
+	Name        string
+	Applied     bool
+	AppliedDate *time.Time
+}
+
+type MigrateResponse struct {
+	Migrations []MigrationStatus
+}
+
+type GetMigrationStatusResponse struct {
+	Migrations []MigrationStatus
+}
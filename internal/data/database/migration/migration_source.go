@@ -0,0 +1,85 @@
+package migration
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migration
+// A schema change read from migrations/<version>_<name>.up.sql and its matching .down.sql.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// loadMigrations
+// Returns the migrations found in files ordered by version. Every version must have both an up and a down script.
+func loadMigrations(files fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(files, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, name := range names {
+		base := path.Base(name)
+		var direction string
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s must end with .up.sql or .down.sql", base)
+		}
+
+		prefix, title, found := strings.Cut(strings.TrimSuffix(base, "."+direction+".sql"), "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if !found || err != nil {
+			return nil, fmt.Errorf("migration %s must be named <version>_<name>.%s.sql", base, direction)
+		}
+
+		content, err := fs.ReadFile(files, name)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: title}
+			byVersion[version] = migration
+		} else if migration.Name != title {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, title)
+		}
+
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both an up and a down script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
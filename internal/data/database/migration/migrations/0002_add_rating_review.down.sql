@@ -0,0 +1,4 @@
+ALTER TABLE ratings
+    DROP COLUMN IF EXISTS comment,
+    DROP COLUMN IF EXISTS title,
+    DROP COLUMN IF EXISTS updated_date;
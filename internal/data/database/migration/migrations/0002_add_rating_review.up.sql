@@ -0,0 +1,4 @@
+ALTER TABLE ratings
+    ADD COLUMN IF NOT EXISTS updated_date timestamp,
+    ADD COLUMN IF NOT EXISTS title        varchar(100),
+    ADD COLUMN IF NOT EXISTS comment      text;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS rating_criteria
+(
+    rating_id bigint      NOT NULL
+        CONSTRAINT rating_criteria_ratings_fk
+        REFERENCES ratings (id)
+        ON DELETE CASCADE,
+    criterion varchar(32) NOT NULL,
+    rate      int         NOT NULL,
+    CONSTRAINT rating_criteria_pk
+        PRIMARY KEY (rating_id, criterion)
+);
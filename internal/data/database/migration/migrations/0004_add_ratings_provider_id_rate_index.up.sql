@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS ix_ratings_provider_id_rate
+    ON ratings (provider_id, rate);
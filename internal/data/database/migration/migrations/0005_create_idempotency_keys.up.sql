@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys
+(
+    scope         varchar(64)  NOT NULL,
+    key           varchar(255) NOT NULL,
+    request_hash  char(64)     NOT NULL,
+    status_code   int,
+    content_type  varchar(255),
+    response_body bytea,
+    created_date  timestamp    NOT NULL,
+    CONSTRAINT idempotency_keys_pk
+        PRIMARY KEY (scope, key)
+);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id             bigserial
+        CONSTRAINT api_keys_pk
+        PRIMARY KEY,
+    name           varchar(100) NOT NULL,
+    prefix         varchar(16)  NOT NULL,
+    key_hash       char(64)     NOT NULL,
+    scopes         text[]       NOT NULL,
+    created_date   timestamp    NOT NULL,
+    last_used_date timestamp,
+    revoked_date   timestamp
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uix_api_keys_key_hash
+    ON api_keys (key_hash);
@@ -0,0 +1,2 @@
+ALTER TABLE ratings
+    ADD COLUMN IF NOT EXISTS hidden_date timestamp;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS ix_ratings_provider_id_id;
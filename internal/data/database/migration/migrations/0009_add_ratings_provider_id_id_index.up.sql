@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS ix_ratings_provider_id_id
+    ON ratings (provider_id, id DESC);
@@ -0,0 +1,9 @@
+-- keys of different callers may collide once the subject is dropped, stored responses are only a retry cache
+DELETE FROM idempotency_keys;
+ALTER TABLE idempotency_keys
+    DROP CONSTRAINT IF EXISTS idempotency_keys_pk;
+ALTER TABLE idempotency_keys
+    DROP COLUMN IF EXISTS subject;
+ALTER TABLE idempotency_keys
+    ADD CONSTRAINT idempotency_keys_pk
+        PRIMARY KEY (scope, key);
@@ -0,0 +1,7 @@
+ALTER TABLE idempotency_keys
+    ADD COLUMN IF NOT EXISTS subject varchar(255) NOT NULL DEFAULT '';
+ALTER TABLE idempotency_keys
+    DROP CONSTRAINT IF EXISTS idempotency_keys_pk;
+ALTER TABLE idempotency_keys
+    ADD CONSTRAINT idempotency_keys_pk
+        PRIMARY KEY (scope, subject, key);
@@ -0,0 +1,50 @@
+package rating
+
+import "database/sql"
+
+type IRateCursor interface {
+	Next() bool
+	Record() (*RateRecord, error)
+	Err() error
+	Close() error
+}
+
+// RateCursor
+// Iterates ratings row by row as the database sends them, without loading the result into memory.
+type RateCursor struct {
+	rows *sql.Rows
+}
+
+// rateRecordColumns
+// Select list read by scanRateRecord.
+const rateRecordColumns = `id, username, provider_id, service_id, rate, coalesce(title, ''), coalesce(comment, ''), created_date`
+
+func (c *RateCursor) Next() bool {
+	return c.rows.Next()
+}
+
+func (c *RateCursor) Record() (*RateRecord, error) {
+	record, err := scanRateRecord(c.rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+func (c *RateCursor) Err() error {
+	return c.rows.Err()
+}
+
+func (c *RateCursor) Close() error {
+	return c.rows.Close()
+}
+
+func scanRateRecord(rows *sql.Rows) (RateRecord, error) {
+	var record RateRecord
+	var createdDate sql.NullTime
+	err := rows.Scan(&record.Id, &record.UserName, &record.ProviderId, &record.ServiceId, &record.Rate, &record.Title, &record.Comment, &createdDate)
+	record.CreatedDate = createdDate.Time
+
+	return record, err
+}
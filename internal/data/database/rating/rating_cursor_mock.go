@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/data/database/rating/rating_cursor.go
+
+// Package rating is a generated GoMock package.
+package rating
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRateCursor is a mock of IRateCursor interface.
+type MockIRateCursor struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRateCursorMockRecorder
+}
+
+// MockIRateCursorMockRecorder is the mock recorder for MockIRateCursor.
+type MockIRateCursorMockRecorder struct {
+	mock *MockIRateCursor
+}
+
+// NewMockIRateCursor creates a new mock instance.
+func NewMockIRateCursor(ctrl *gomock.Controller) *MockIRateCursor {
+	mock := &MockIRateCursor{ctrl: ctrl}
+	mock.recorder = &MockIRateCursorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRateCursor) EXPECT() *MockIRateCursorMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockIRateCursor) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIRateCursorMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIRateCursor)(nil).Close))
+}
+
+// Err mocks base method.
+func (m *MockIRateCursor) Err() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Err")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Err indicates an expected call of Err.
+func (mr *MockIRateCursorMockRecorder) Err() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Err", reflect.TypeOf((*MockIRateCursor)(nil).Err))
+}
+
+// Next mocks base method.
+func (m *MockIRateCursor) Next() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockIRateCursorMockRecorder) Next() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockIRateCursor)(nil).Next))
+}
+
+// Record mocks base method.
+func (m *MockIRateCursor) Record() (*RateRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record")
+	ret0, _ := ret[0].(*RateRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockIRateCursorMockRecorder) Record() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockIRateCursor)(nil).Record))
+}
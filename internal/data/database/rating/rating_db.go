@@ -4,137 +4,756 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"rating-api/internal/data/database"
 	"rating-api/internal/util/env"
 	"rating-api/internal/util/logger"
 	"rating-api/internal/util/validator"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 type IRatingDb interface {
-	AddRate(ch chan *AddRatingResponse, model *AddRatingModel)
-	GetAllRate(ch chan *GetAllRatingsResponse, model *GetAllRatingsModel)
+	AddRate(ctx context.Context, model *AddRatingModel) (*AddRatingResponse, error)
+	AddRates(ctx context.Context, model *AddRatesModel) (*AddRatesResponse, error)
+	FindRates(ctx context.Context, model *FindRatesModel) (*FindRatesResponse, error)
+	GetAllRate(ctx context.Context, model *GetAllRatingsModel) (*GetAllRatingsResponse, error)
+	GetRateAggregate(ctx context.Context, model *GetRateAggregateModel) (*GetRateAggregateResponse, error)
+	GetRateAggregates(ctx context.Context, model *GetRateAggregatesModel) (*GetRateAggregatesResponse, error)
+	GetTopProviders(ctx context.Context, model *GetTopProvidersModel) (*GetTopProvidersResponse, error)
+	GetCriteriaAverage(ctx context.Context, model *GetCriteriaAverageModel) (*GetCriteriaAverageResponse, error)
+	GetRateDistribution(ctx context.Context, model *GetRateDistributionModel) (*GetRateDistributionResponse, error)
+	ListRates(ctx context.Context, model *ListRatesModel) (*ListRatesResponse, error)
+	ExportRates(ctx context.Context, model *ExportRatesModel) (IRateCursor, error)
+	UpdateRate(ctx context.Context, model *UpdateRatingModel) (*UpdateRatingResponse, error)
+	DeleteRate(ctx context.Context, model *DeleteRatingModel) (*DeleteRatingResponse, error)
+	HideRate(ctx context.Context, model *HideRatingModel) (*HideRatingResponse, error)
+	Close() error
 }
 
+var (
+	ErrRateNotFound  = errors.New("rate not found")
+	ErrRateNotOwned  = errors.New("rate belongs to another user")
+	ErrRateDuplicate = errors.New("rate already exists")
+)
+
 type RatingDb struct {
-	loggr            logger.ILogger
-	validatr         validator.IValidator
-	environment      env.IEnvironment
-	connectionString string
-	driverName       string
-	timeout          time.Duration
+	loggr          logger.ILogger
+	validatr       validator.IValidator
+	environment    env.IEnvironment
+	timeout        time.Duration
+	connection     *sql.DB
+	ownsConnection bool
 }
 
 // NewRatingDb
-// Returns a new RatingDb.
-func NewRatingDb(loggr logger.ILogger, validatr validator.IValidator, environment env.IEnvironment) IRatingDb {
+// Returns a new RatingDb on the shared connection pool, or on a pool of its own when connection is nil.
+// Query durations and failures are exported as metrics.
+func NewRatingDb(loggr logger.ILogger, validatr validator.IValidator, environment env.IEnvironment, connection *sql.DB) IRatingDb {
 	db := RatingDb{
-		environment:      environment,
-		loggr:            loggr,
-		validatr:         validatr,
-		driverName:       "postgres",
-		connectionString: environment.Get(env.PostgresqlConnectionString),
-		timeout:          time.Second * 5,
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+		timeout:     time.Second * 5,
+		connection:  connection,
+	}
+
+	if connection == nil {
+		db.connection = database.Open(loggr, environment)
+		db.ownsConnection = true
+	}
+
+	return &meteredRatingDb{db: &db}
+}
+
+// Close
+// Closes the connection pool if RatingDb opened it itself. A shared pool is closed by its owner.
+func (d *RatingDb) Close() error {
+	if !d.ownsConnection {
+		return nil
 	}
 
-	return &db
+	return d.connection.Close()
 }
 
 // AddRate
-// Add rating for a service provider.
-func (d *RatingDb) AddRate(ch chan *AddRatingResponse, model *AddRatingModel) {
+// Add rating for a service provider together with its criteria scores.
+func (d *RatingDb) AddRate(ctx context.Context, model *AddRatingModel) (*AddRatingResponse, error) {
 	modelErr := d.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		d.loggr.Error(modelErr.Error())
-		ch <- &AddRatingResponse{Error: modelErr}
-		return
+		return nil, modelErr
 	}
 
-	connection, err := sql.Open(d.driverName, d.connectionString)
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `insert into ratings (username, provider_id, service_id, rate, title, comment, created_date) 
+				values ($1, $2, $3, $4, nullif($5, ''), nullif($6, ''), current_timestamp)
+				on conflict(service_id)
+				do nothing
+				returning id`
+
+	tx, err := d.connection.BeginTx(ctx, nil)
 	if err != nil {
 		d.loggr.Error(err.Error())
-		ch <- &AddRatingResponse{Error: err}
-		return
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var response AddRatingResponse
+	dbErr := tx.QueryRowContext(ctx, query, model.UserName, model.ProviderId, model.ServiceId, model.Rate, model.Title, model.Comment).Scan(&response.Id)
+	if dbErr == sql.ErrNoRows {
+		err := fmt.Errorf("%w for ServiceId: %s", ErrRateDuplicate, model.ServiceId)
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+
+	var criteria ratingCriteriaRows
+	criteria.add(response.Id, model.Criteria)
+	if err := d.insertCriteria(ctx, tx, &criteria); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
 	}
-	defer connection.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	return &response, nil
+}
+
+// AddRates
+// Add many ratings with their criteria scores in a single transaction.
+// Ratings whose ServiceId already exists are skipped and left out of the returned Ids.
+func (d *RatingDb) AddRates(ctx context.Context, model *AddRatesModel) (*AddRatesResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
 	defer cancel()
 
-	query := `insert into ratings (username, provider_id, service_id, rate, created_date) 
-				values ($1, $2, $3, $4, current_timestamp)
+	count := len(model.Ratings)
+	userNames, providerIds, serviceIds := make([]string, count), make([]string, count), make([]string, count)
+	rates, titles, comments := make([]int64, count), make([]string, count), make([]string, count)
+	for i, item := range model.Ratings {
+		userNames[i], providerIds[i], serviceIds[i] = item.UserName, item.ProviderId, item.ServiceId
+		rates[i], titles[i], comments[i] = int64(item.Rate), item.Title, item.Comment
+	}
+
+	query := `insert into ratings (username, provider_id, service_id, rate, title, comment, created_date)
+				select username, provider_id, service_id, rate, nullif(title, ''), nullif(comment, ''), current_timestamp
+				from unnest($1::varchar[], $2::varchar[], $3::varchar[], $4::int[], $5::varchar[], $6::text[])
+					as r(username, provider_id, service_id, rate, title, comment)
 				on conflict(service_id)
-				do nothing`
+				do nothing
+				returning service_id, id`
 
-	result, dbErr := connection.ExecContext(ctx, query, model.UserName, model.ProviderId, model.ServiceId, model.Rate)
+	tx, err := d.connection.BeginTx(ctx, nil)
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, dbErr := tx.QueryContext(ctx, query, pq.Array(userNames), pq.Array(providerIds), pq.Array(serviceIds),
+		pq.Array(rates), pq.Array(titles), pq.Array(comments))
 	if dbErr != nil {
 		d.loggr.Error(dbErr.Error())
-		ch <- &AddRatingResponse{Error: dbErr}
-		return
+		return nil, dbErr
 	}
 
-	rows, err := result.RowsAffected()
-	if err != nil {
+	response := AddRatesResponse{Ids: make(map[string]int64, count)}
+	for rows.Next() {
+		var serviceId string
+		var id int64
+		if err := rows.Scan(&serviceId, &id); err != nil {
+			rows.Close()
+			d.loggr.Error(err.Error())
+			return nil, err
+		}
+		response.Ids[serviceId] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
 		d.loggr.Error(err.Error())
-		ch <- &AddRatingResponse{Error: err}
-		return
+		return nil, err
+	}
+
+	var criteria ratingCriteriaRows
+	for _, item := range model.Ratings {
+		if id, ok := response.Ids[item.ServiceId]; ok {
+			criteria.add(id, item.Criteria)
+		}
+	}
+	if err := d.insertCriteria(ctx, tx, &criteria); err != nil {
+		return nil, err
 	}
 
-	if rows != 1 {
-		d.loggr.Error("could not add rate")
-		ch <- &AddRatingResponse{Error: errors.New("could not add rate")}
-		return
+	if err := tx.Commit(); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
 	}
 
-	ch <- &AddRatingResponse{}
+	return &response, nil
+}
+
+// FindRates
+// Get the ids of the ratings that exist for the given ServiceIds, hidden ones included.
+// ServiceIds without a rating are left out of Ids.
+func (d *RatingDb) FindRates(ctx context.Context, model *FindRatesModel) (*FindRatesResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `select service_id, id from ratings where service_id = any($1)`
+
+	rows, dbErr := d.connection.QueryContext(ctx, query, pq.Array(model.ServiceIds))
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+	defer rows.Close()
+
+	response := FindRatesResponse{Ids: map[string]int64{}}
+	for rows.Next() {
+		var serviceId string
+		var id int64
+		if err := rows.Scan(&serviceId, &id); err != nil {
+			d.loggr.Error(err.Error())
+			return nil, err
+		}
+		response.Ids[serviceId] = id
+	}
+	if err := rows.Err(); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &response, nil
 }
 
 // GetAllRate
 // Get all ratings for a service provider.
-func (d *RatingDb) GetAllRate(ch chan *GetAllRatingsResponse, model *GetAllRatingsModel) {
+func (d *RatingDb) GetAllRate(ctx context.Context, model *GetAllRatingsModel) (*GetAllRatingsResponse, error) {
 	modelErr := d.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		d.loggr.Error(modelErr.Error())
-		ch <- &GetAllRatingsResponse{Error: modelErr}
-		return
+		return nil, modelErr
 	}
 
-	connection, err := sql.Open(d.driverName, d.connectionString)
-	if err != nil {
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `select rate from ratings where provider_id = $1 and ` + visibleRate
+
+	rows, dbErr := d.connection.QueryContext(ctx, query, model.ProviderId)
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+	defer rows.Close()
+
+	response := GetAllRatingsResponse{Rates: []int{}}
+	for rows.Next() {
+		var rate int
+		if err := rows.Scan(&rate); err != nil {
+			d.loggr.Error(err.Error())
+			return nil, err
+		}
+		response.Rates = append(response.Rates, rate)
+	}
+	if err := rows.Err(); err != nil {
 		d.loggr.Error(err.Error())
-		ch <- &GetAllRatingsResponse{Error: err}
-		return
+		return nil, err
 	}
-	defer connection.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	return &response, nil
+}
+
+// GetRateAggregate
+// Get count, sum, average, min and max of ratings for a service provider computed by the database.
+// Positive is the number of 4 and 5 star ratings. When HalfLife is set, DecayedAverage weights every rating
+// by 0.5^(age/HalfLife). Since and Until optionally restrict ratings to a creation date range.
+// Returns a zero Count when the provider has no ratings.
+func (d *RatingDb) GetRateAggregate(ctx context.Context, model *GetRateAggregateModel) (*GetRateAggregateResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
 	defer cancel()
 
-	query := `select rate from ratings where provider_id = $1`
+	var filter queryFilter
+	columns := rateAggregateColumns(&filter, model.HalfLife)
+	filter.add("provider_id =", model.ProviderId)
+	filter.require(visibleRate)
+	addDateRange(&filter, model.Since, model.Until)
 
-	rows, dbErr := connection.QueryContext(ctx, query, model.ProviderId)
+	query := `select ` + columns + `
+				from ratings
+				where ` + filter.where() + `
+				group by provider_id`
 
-	if dbErr != nil && dbErr != sql.ErrNoRows {
+	var response GetRateAggregateResponse
+	dbErr := d.connection.QueryRowContext(ctx, query, filter.args...).Scan(response.scanDest()...)
+	if dbErr == sql.ErrNoRows {
+		return &GetRateAggregateResponse{}, nil
+	}
+	if dbErr != nil {
 		d.loggr.Error(dbErr.Error())
-		ch <- &GetAllRatingsResponse{Error: dbErr}
-		return
+		return nil, dbErr
 	}
-	if dbErr == sql.ErrNoRows {
-		ch <- &GetAllRatingsResponse{Rates: []int{}}
-		return
+
+	return &response, nil
+}
+
+// GetRateAggregates
+// Get the same aggregates as GetRateAggregate for many service providers in one query.
+// Providers without ratings are left out of Aggregates.
+func (d *RatingDb) GetRateAggregates(ctx context.Context, model *GetRateAggregatesModel) (*GetRateAggregatesResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
 	}
 
-	var response GetAllRatingsResponse
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	var filter queryFilter
+	columns := rateAggregateColumns(&filter, model.HalfLife)
+
+	query := `select provider_id, ` + columns + `
+				from ratings
+				where provider_id = any(` + filter.arg(pq.Array(model.ProviderIds)) + `) and ` + visibleRate + `
+				group by provider_id`
+
+	rows, dbErr := d.connection.QueryContext(ctx, query, filter.args...)
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+	defer rows.Close()
+
+	response := GetRateAggregatesResponse{Aggregates: map[string]GetRateAggregateResponse{}}
 	for rows.Next() {
-		var rate int
-		if err := rows.Scan(&rate); err != nil {
+		var providerId string
+		var aggregate GetRateAggregateResponse
+		if err := rows.Scan(append([]interface{}{&providerId}, aggregate.scanDest()...)...); err != nil {
 			d.loggr.Error(err.Error())
-			ch <- &GetAllRatingsResponse{Error: err}
-			return
+			return nil, err
 		}
-		response.Rates = append(response.Rates, rate)
+		response.Aggregates[providerId] = aggregate
+	}
+	if err := rows.Err(); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetTopProviders
+// Get providers with at least MinCount ratings ordered by score, highest first.
+// Score is the average pulled towards PriorMean as if PriorWeight extra ratings were given,
+// which is the plain average when PriorWeight is zero.
+func (d *RatingDb) GetTopProviders(ctx context.Context, model *GetTopProvidersModel) (*GetTopProvidersResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `select provider_id, count(rate), avg(rate),
+				($1::float8 * $2::float8 + sum(rate)) / ($2::float8 + count(rate)) as score
+				from ratings
+				where ` + visibleRate + `
+				group by provider_id
+				having count(rate) >= $3
+				order by score desc, count(rate) desc, provider_id
+				limit $4`
+
+	rows, dbErr := d.connection.QueryContext(ctx, query, model.PriorMean, model.PriorWeight, model.MinCount, model.Limit)
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+	defer rows.Close()
+
+	response := GetTopProvidersResponse{Providers: []ProviderScore{}}
+	for rows.Next() {
+		var provider ProviderScore
+		if err := rows.Scan(&provider.ProviderId, &provider.Count, &provider.Average, &provider.Score); err != nil {
+			d.loggr.Error(err.Error())
+			return nil, err
+		}
+		response.Providers = append(response.Providers, provider)
+	}
+	if err := rows.Err(); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetCriteriaAverage
+// Get the average score of every criterion rated for a service provider.
+// Since and Until optionally restrict ratings to a creation date range.
+func (d *RatingDb) GetCriteriaAverage(ctx context.Context, model *GetCriteriaAverageModel) (*GetCriteriaAverageResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	var filter queryFilter
+	filter.add("r.provider_id =", model.ProviderId)
+	filter.require("r." + visibleRate)
+	if !model.Since.IsZero() {
+		filter.add("r.created_date >=", model.Since)
+	}
+	if !model.Until.IsZero() {
+		filter.add("r.created_date <", model.Until)
+	}
+
+	query := `select c.criterion, avg(c.rate)
+				from rating_criteria c
+				join ratings r on r.id = c.rating_id
+				where ` + filter.where() + `
+				group by c.criterion`
+
+	rows, dbErr := d.connection.QueryContext(ctx, query, filter.args...)
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+	defer rows.Close()
+
+	response := GetCriteriaAverageResponse{Averages: map[string]float64{}}
+	for rows.Next() {
+		var criterion string
+		var average float64
+		if err := rows.Scan(&criterion, &average); err != nil {
+			d.loggr.Error(err.Error())
+			return nil, err
+		}
+		response.Averages[criterion] = average
+	}
+	if err := rows.Err(); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetRateDistribution
+// Get the number of ratings per rate value for a service provider.
+func (d *RatingDb) GetRateDistribution(ctx context.Context, model *GetRateDistributionModel) (*GetRateDistributionResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `select rate, count(*)
+				from ratings
+				where provider_id = $1 and ` + visibleRate + `
+				group by rate`
+
+	rows, dbErr := d.connection.QueryContext(ctx, query, model.ProviderId)
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+	defer rows.Close()
+
+	response := GetRateDistributionResponse{Counts: map[int]int{}}
+	for rows.Next() {
+		var rate, count int
+		if err := rows.Scan(&rate, &count); err != nil {
+			d.loggr.Error(err.Error())
+			return nil, err
+		}
+		response.Counts[rate] = count
+	}
+	if err := rows.Err(); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListRates
+// Get ratings of a service provider newest first, starting below BeforeId when it is set.
+// MinRate, MaxRate, Since and Until are optional filters ignored when left zero.
+func (d *RatingDb) ListRates(ctx context.Context, model *ListRatesModel) (*ListRatesResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	var filter queryFilter
+	filter.add("provider_id =", model.ProviderId)
+	filter.require(visibleRate)
+	if model.BeforeId > 0 {
+		filter.add("id <", model.BeforeId)
+	}
+	if model.MinRate > 0 {
+		filter.add("rate >=", model.MinRate)
+	}
+	if model.MaxRate > 0 {
+		filter.add("rate <=", model.MaxRate)
+	}
+	addDateRange(&filter, model.Since, model.Until)
+
+	query := `select ` + rateRecordColumns + `
+				from ratings
+				where ` + filter.where() + `
+				order by id desc
+				limit ` + filter.arg(model.Limit)
+
+	rows, dbErr := d.connection.QueryContext(ctx, query, filter.args...)
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+	defer rows.Close()
+
+	response := ListRatesResponse{Rates: []RateRecord{}}
+	for rows.Next() {
+		record, err := scanRateRecord(rows)
+		if err != nil {
+			d.loggr.Error(err.Error())
+			return nil, err
+		}
+		response.Rates = append(response.Rates, record)
+	}
+	if err := rows.Err(); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ExportRates
+// Get a cursor over all ratings oldest first, optionally of one service provider and created since a date.
+// The query is bound to ctx only, not to the RatingDb timeout, so a long export runs until the caller
+// cancels it. The cursor must be closed to release its connection.
+func (d *RatingDb) ExportRates(ctx context.Context, model *ExportRatesModel) (IRateCursor, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	var filter queryFilter
+	if model.ProviderId != "" {
+		filter.add("provider_id =", model.ProviderId)
+	}
+	filter.require(visibleRate)
+	addDateRange(&filter, model.Since, time.Time{})
+
+	query := `select ` + rateRecordColumns + `
+				from ratings
+				where ` + filter.where() + `
+				order by id`
+
+	rows, dbErr := d.connection.QueryContext(ctx, query, filter.args...)
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+
+	return &RateCursor{rows: rows}, nil
+}
+
+// UpdateRate
+// Replace the rate, title and comment of an existing rating. Only the user who added the rating can change it.
+func (d *RatingDb) UpdateRate(ctx context.Context, model *UpdateRatingModel) (*UpdateRatingResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	tx, err := d.connection.BeginTx(ctx, nil)
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	id, err := d.lockOwnedRate(ctx, tx, model.ServiceId, model.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `update ratings
+				set rate = $1, title = nullif($2, ''), comment = nullif($3, ''), updated_date = current_timestamp
+				where id = $4`
+
+	if _, err := tx.ExecContext(ctx, query, model.Rate, model.Title, model.Comment, id); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &UpdateRatingResponse{Id: id}, nil
+}
+
+// DeleteRate
+// Retract an existing rating. Only the user who added the rating can retract it, unless AnyOwner is set.
+func (d *RatingDb) DeleteRate(ctx context.Context, model *DeleteRatingModel) (*DeleteRatingResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	tx, err := d.connection.BeginTx(ctx, nil)
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	owner := model.UserName
+	if model.AnyOwner {
+		owner = ""
+	}
+	id, err := d.lockOwnedRate(ctx, tx, model.ServiceId, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `delete from ratings where id = $1`
+
+	if _, err := tx.ExecContext(ctx, query, id); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		d.loggr.Error(err.Error())
+		return nil, err
+	}
+
+	return &DeleteRatingResponse{Id: id}, nil
+}
+
+// HideRate
+// Hide an existing rating from every read, or show it again. Hiding an already hidden rating keeps its hidden date.
+func (d *RatingDb) HideRate(ctx context.Context, model *HideRatingModel) (*HideRatingResponse, error) {
+	modelErr := d.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		d.loggr.Error(modelErr.Error())
+		return nil, modelErr
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	query := `update ratings
+				set hidden_date = case when $2 then coalesce(hidden_date, current_timestamp) end
+				where service_id = $1
+				returning id`
+
+	var response HideRatingResponse
+	dbErr := d.connection.QueryRowContext(ctx, query, model.ServiceId, model.Hidden).Scan(&response.Id)
+	if dbErr == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w for ServiceId: %s", ErrRateNotFound, model.ServiceId)
+	}
+	if dbErr != nil {
+		d.loggr.Error(dbErr.Error())
+		return nil, dbErr
+	}
+
+	return &response, nil
+}
+
+// insertCriteria
+// Inserts the collected criteria scores within the transaction in one statement.
+func (d *RatingDb) insertCriteria(ctx context.Context, tx *sql.Tx, criteria *ratingCriteriaRows) error {
+	if len(criteria.ratingIds) == 0 {
+		return nil
+	}
+
+	query := `insert into rating_criteria (rating_id, criterion, rate)
+				select unnest($1::bigint[]), unnest($2::varchar[]), unnest($3::int[])`
+
+	_, err := tx.ExecContext(ctx, query, pq.Array(criteria.ratingIds), pq.Array(criteria.criteria), pq.Array(criteria.rates))
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// lockOwnedRate
+// Locks the rating of a service for the rest of the transaction and returns its id,
+// or ErrRateNotFound / ErrRateNotOwned when it does not exist or belongs to another user.
+// An empty userName skips the owner check.
+func (d *RatingDb) lockOwnedRate(ctx context.Context, tx *sql.Tx, serviceId string, userName string) (int64, error) {
+	query := `select id, username from ratings where service_id = $1 for update`
+
+	var id int64
+	var owner string
+	err := tx.QueryRowContext(ctx, query, serviceId).Scan(&id, &owner)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("%w for ServiceId: %s", ErrRateNotFound, serviceId)
+	}
+	if err != nil {
+		d.loggr.Error(err.Error())
+		return 0, err
+	}
+
+	if userName != "" && owner != userName {
+		return 0, fmt.Errorf("%w for ServiceId: %s", ErrRateNotOwned, serviceId)
 	}
 
-	ch <- &response
+	return id, nil
 }
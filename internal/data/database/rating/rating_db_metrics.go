@@ -0,0 +1,131 @@
+package rating
+
+import (
+	"context"
+	"errors"
+	"rating-api/internal/util/metrics"
+	"rating-api/internal/util/validator"
+	"time"
+)
+
+// meteredRatingDb
+// Records the duration and failures of every RatingDb query. ExportRates is timed until its cursor is returned.
+type meteredRatingDb struct {
+	db IRatingDb
+}
+
+// observe
+// Records a query, counting it as failed unless it succeeded or was rejected for invalid input or a missing,
+// foreign or duplicate rating.
+func observe(query string, start time.Time, err error) {
+	var validationErr *validator.ValidationError
+	failed := err != nil &&
+		!errors.As(err, &validationErr) &&
+		!errors.Is(err, ErrRateNotFound) &&
+		!errors.Is(err, ErrRateNotOwned) &&
+		!errors.Is(err, ErrRateDuplicate)
+
+	metrics.ObserveQuery(query, start, failed)
+}
+
+func (m *meteredRatingDb) AddRate(ctx context.Context, model *AddRatingModel) (*AddRatingResponse, error) {
+	start := time.Now()
+	response, err := m.db.AddRate(ctx, model)
+	observe("AddRate", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) AddRates(ctx context.Context, model *AddRatesModel) (*AddRatesResponse, error) {
+	start := time.Now()
+	response, err := m.db.AddRates(ctx, model)
+	observe("AddRates", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) FindRates(ctx context.Context, model *FindRatesModel) (*FindRatesResponse, error) {
+	start := time.Now()
+	response, err := m.db.FindRates(ctx, model)
+	observe("FindRates", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) GetAllRate(ctx context.Context, model *GetAllRatingsModel) (*GetAllRatingsResponse, error) {
+	start := time.Now()
+	response, err := m.db.GetAllRate(ctx, model)
+	observe("GetAllRate", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) GetRateAggregate(ctx context.Context, model *GetRateAggregateModel) (*GetRateAggregateResponse, error) {
+	start := time.Now()
+	response, err := m.db.GetRateAggregate(ctx, model)
+	observe("GetRateAggregate", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) GetRateAggregates(ctx context.Context, model *GetRateAggregatesModel) (*GetRateAggregatesResponse, error) {
+	start := time.Now()
+	response, err := m.db.GetRateAggregates(ctx, model)
+	observe("GetRateAggregates", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) GetTopProviders(ctx context.Context, model *GetTopProvidersModel) (*GetTopProvidersResponse, error) {
+	start := time.Now()
+	response, err := m.db.GetTopProviders(ctx, model)
+	observe("GetTopProviders", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) GetCriteriaAverage(ctx context.Context, model *GetCriteriaAverageModel) (*GetCriteriaAverageResponse, error) {
+	start := time.Now()
+	response, err := m.db.GetCriteriaAverage(ctx, model)
+	observe("GetCriteriaAverage", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) GetRateDistribution(ctx context.Context, model *GetRateDistributionModel) (*GetRateDistributionResponse, error) {
+	start := time.Now()
+	response, err := m.db.GetRateDistribution(ctx, model)
+	observe("GetRateDistribution", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) ListRates(ctx context.Context, model *ListRatesModel) (*ListRatesResponse, error) {
+	start := time.Now()
+	response, err := m.db.ListRates(ctx, model)
+	observe("ListRates", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) ExportRates(ctx context.Context, model *ExportRatesModel) (IRateCursor, error) {
+	start := time.Now()
+	cursor, err := m.db.ExportRates(ctx, model)
+	observe("ExportRates", start, err)
+	return cursor, err
+}
+
+func (m *meteredRatingDb) UpdateRate(ctx context.Context, model *UpdateRatingModel) (*UpdateRatingResponse, error) {
+	start := time.Now()
+	response, err := m.db.UpdateRate(ctx, model)
+	observe("UpdateRate", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) DeleteRate(ctx context.Context, model *DeleteRatingModel) (*DeleteRatingResponse, error) {
+	start := time.Now()
+	response, err := m.db.DeleteRate(ctx, model)
+	observe("DeleteRate", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) HideRate(ctx context.Context, model *HideRatingModel) (*HideRatingResponse, error) {
+	start := time.Now()
+	response, err := m.db.HideRate(ctx, model)
+	observe("HideRate", start, err)
+	return response, err
+}
+
+func (m *meteredRatingDb) Close() error {
+	return m.db.Close()
+}
@@ -5,6 +5,7 @@
 package rating
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,25 +35,225 @@ func (m *MockIRatingDb) EXPECT() *MockIRatingDbMockRecorder {
 }
 
 // AddRate mocks base method.
-func (m *MockIRatingDb) AddRate(ch chan *AddRatingResponse, model *AddRatingModel) {
+func (m *MockIRatingDb) AddRate(ctx context.Context, model *AddRatingModel) (*AddRatingResponse, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "AddRate", ch, model)
+	ret := m.ctrl.Call(m, "AddRate", ctx, model)
+	ret0, _ := ret[0].(*AddRatingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // AddRate indicates an expected call of AddRate.
-func (mr *MockIRatingDbMockRecorder) AddRate(ch, model interface{}) *gomock.Call {
+func (mr *MockIRatingDbMockRecorder) AddRate(ctx, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRate", reflect.TypeOf((*MockIRatingDb)(nil).AddRate), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRate", reflect.TypeOf((*MockIRatingDb)(nil).AddRate), ctx, model)
+}
+
+// AddRates mocks base method.
+func (m *MockIRatingDb) AddRates(ctx context.Context, model *AddRatesModel) (*AddRatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRates", ctx, model)
+	ret0, _ := ret[0].(*AddRatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRates indicates an expected call of AddRates.
+func (mr *MockIRatingDbMockRecorder) AddRates(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRates", reflect.TypeOf((*MockIRatingDb)(nil).AddRates), ctx, model)
+}
+
+// Close mocks base method.
+func (m *MockIRatingDb) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockIRatingDbMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockIRatingDb)(nil).Close))
+}
+
+// DeleteRate mocks base method.
+func (m *MockIRatingDb) DeleteRate(ctx context.Context, model *DeleteRatingModel) (*DeleteRatingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRate", ctx, model)
+	ret0, _ := ret[0].(*DeleteRatingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRate indicates an expected call of DeleteRate.
+func (mr *MockIRatingDbMockRecorder) DeleteRate(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRate", reflect.TypeOf((*MockIRatingDb)(nil).DeleteRate), ctx, model)
+}
+
+// ExportRates mocks base method.
+func (m *MockIRatingDb) ExportRates(ctx context.Context, model *ExportRatesModel) (IRateCursor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportRates", ctx, model)
+	ret0, _ := ret[0].(IRateCursor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportRates indicates an expected call of ExportRates.
+func (mr *MockIRatingDbMockRecorder) ExportRates(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportRates", reflect.TypeOf((*MockIRatingDb)(nil).ExportRates), ctx, model)
+}
+
+// FindRates mocks base method.
+func (m *MockIRatingDb) FindRates(ctx context.Context, model *FindRatesModel) (*FindRatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRates", ctx, model)
+	ret0, _ := ret[0].(*FindRatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRates indicates an expected call of FindRates.
+func (mr *MockIRatingDbMockRecorder) FindRates(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRates", reflect.TypeOf((*MockIRatingDb)(nil).FindRates), ctx, model)
 }
 
 // GetAllRate mocks base method.
-func (m *MockIRatingDb) GetAllRate(ch chan *GetAllRatingsResponse, model *GetAllRatingsModel) {
+func (m *MockIRatingDb) GetAllRate(ctx context.Context, model *GetAllRatingsModel) (*GetAllRatingsResponse, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetAllRate", ch, model)
+	ret := m.ctrl.Call(m, "GetAllRate", ctx, model)
+	ret0, _ := ret[0].(*GetAllRatingsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetAllRate indicates an expected call of GetAllRate.
-func (mr *MockIRatingDbMockRecorder) GetAllRate(ch, model interface{}) *gomock.Call {
+func (mr *MockIRatingDbMockRecorder) GetAllRate(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllRate", reflect.TypeOf((*MockIRatingDb)(nil).GetAllRate), ctx, model)
+}
+
+// GetCriteriaAverage mocks base method.
+func (m *MockIRatingDb) GetCriteriaAverage(ctx context.Context, model *GetCriteriaAverageModel) (*GetCriteriaAverageResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCriteriaAverage", ctx, model)
+	ret0, _ := ret[0].(*GetCriteriaAverageResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCriteriaAverage indicates an expected call of GetCriteriaAverage.
+func (mr *MockIRatingDbMockRecorder) GetCriteriaAverage(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCriteriaAverage", reflect.TypeOf((*MockIRatingDb)(nil).GetCriteriaAverage), ctx, model)
+}
+
+// GetRateAggregate mocks base method.
+func (m *MockIRatingDb) GetRateAggregate(ctx context.Context, model *GetRateAggregateModel) (*GetRateAggregateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateAggregate", ctx, model)
+	ret0, _ := ret[0].(*GetRateAggregateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateAggregate indicates an expected call of GetRateAggregate.
+func (mr *MockIRatingDbMockRecorder) GetRateAggregate(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateAggregate", reflect.TypeOf((*MockIRatingDb)(nil).GetRateAggregate), ctx, model)
+}
+
+// GetRateAggregates mocks base method.
+func (m *MockIRatingDb) GetRateAggregates(ctx context.Context, model *GetRateAggregatesModel) (*GetRateAggregatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateAggregates", ctx, model)
+	ret0, _ := ret[0].(*GetRateAggregatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateAggregates indicates an expected call of GetRateAggregates.
+func (mr *MockIRatingDbMockRecorder) GetRateAggregates(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateAggregates", reflect.TypeOf((*MockIRatingDb)(nil).GetRateAggregates), ctx, model)
+}
+
+// GetRateDistribution mocks base method.
+func (m *MockIRatingDb) GetRateDistribution(ctx context.Context, model *GetRateDistributionModel) (*GetRateDistributionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateDistribution", ctx, model)
+	ret0, _ := ret[0].(*GetRateDistributionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateDistribution indicates an expected call of GetRateDistribution.
+func (mr *MockIRatingDbMockRecorder) GetRateDistribution(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateDistribution", reflect.TypeOf((*MockIRatingDb)(nil).GetRateDistribution), ctx, model)
+}
+
+// GetTopProviders mocks base method.
+func (m *MockIRatingDb) GetTopProviders(ctx context.Context, model *GetTopProvidersModel) (*GetTopProvidersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopProviders", ctx, model)
+	ret0, _ := ret[0].(*GetTopProvidersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopProviders indicates an expected call of GetTopProviders.
+func (mr *MockIRatingDbMockRecorder) GetTopProviders(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopProviders", reflect.TypeOf((*MockIRatingDb)(nil).GetTopProviders), ctx, model)
+}
+
+// HideRate mocks base method.
+func (m *MockIRatingDb) HideRate(ctx context.Context, model *HideRatingModel) (*HideRatingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HideRate", ctx, model)
+	ret0, _ := ret[0].(*HideRatingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HideRate indicates an expected call of HideRate.
+func (mr *MockIRatingDbMockRecorder) HideRate(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HideRate", reflect.TypeOf((*MockIRatingDb)(nil).HideRate), ctx, model)
+}
+
+// ListRates mocks base method.
+func (m *MockIRatingDb) ListRates(ctx context.Context, model *ListRatesModel) (*ListRatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRates", ctx, model)
+	ret0, _ := ret[0].(*ListRatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRates indicates an expected call of ListRates.
+func (mr *MockIRatingDbMockRecorder) ListRates(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRates", reflect.TypeOf((*MockIRatingDb)(nil).ListRates), ctx, model)
+}
+
+// UpdateRate mocks base method.
+func (m *MockIRatingDb) UpdateRate(ctx context.Context, model *UpdateRatingModel) (*UpdateRatingResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRate", ctx, model)
+	ret0, _ := ret[0].(*UpdateRatingResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRate indicates an expected call of UpdateRate.
+func (mr *MockIRatingDbMockRecorder) UpdateRate(ctx, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllRate", reflect.TypeOf((*MockIRatingDb)(nil).GetAllRate), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRate", reflect.TypeOf((*MockIRatingDb)(nil).UpdateRate), ctx, model)
 }
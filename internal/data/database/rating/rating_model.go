@@ -1,12 +1,88 @@
 package rating
 
+import "time"
+
 type AddRatingModel struct {
-	UserName   string `validate:"required"`
-	ProviderId string `validate:"required"`
-	ServiceId  string `validate:"required"`
-	Rate       int    `validate:"required,gte=1,lte=5"`
+	UserName   string         `validate:"required,max=36"`
+	ProviderId string         `validate:"required,max=32"`
+	ServiceId  string         `validate:"required,max=32"`
+	Rate       int            `validate:"required,gte=1,lte=5"`
+	Title      string         `validate:"omitempty,max=100"`
+	Comment    string         `validate:"omitempty,max=2000"`
+	Criteria   map[string]int `validate:"omitempty,dive,keys,required,max=32,endkeys,gte=1,lte=5"`
+}
+
+type AddRatesModel struct {
+	Ratings []AddRatingModel `validate:"required,min=1,dive"`
+}
+
+type FindRatesModel struct {
+	ServiceIds []string `validate:"required,min=1"`
 }
 
 type GetAllRatingsModel struct {
 	ProviderId string `validate:"required"`
 }
+
+type GetRateAggregateModel struct {
+	ProviderId string `validate:"required"`
+	Since      time.Time
+	Until      time.Time
+	HalfLife   time.Duration `validate:"gte=0"`
+}
+
+type GetRateDistributionModel struct {
+	ProviderId string `validate:"required"`
+}
+
+type ExportRatesModel struct {
+	ProviderId string
+	Since      time.Time
+}
+
+type UpdateRatingModel struct {
+	UserName  string `validate:"required"`
+	ServiceId string `validate:"required"`
+	Rate      int    `validate:"required,gte=1,lte=5"`
+	Title     string `validate:"omitempty,max=100"`
+	Comment   string `validate:"omitempty,max=2000"`
+}
+
+type DeleteRatingModel struct {
+	UserName  string `validate:"required"`
+	ServiceId string `validate:"required"`
+	AnyOwner  bool
+}
+
+type HideRatingModel struct {
+	ServiceId string `validate:"required"`
+	Hidden    bool
+}
+
+type ListRatesModel struct {
+	ProviderId string `validate:"required"`
+	BeforeId   int64  `validate:"gte=0"`
+	Limit      int    `validate:"required,gte=1"`
+	MinRate    int    `validate:"omitempty,gte=1,lte=5"`
+	MaxRate    int    `validate:"omitempty,gte=1,lte=5"`
+	Since      time.Time
+	Until      time.Time
+}
+
+type GetRateAggregatesModel struct {
+	ProviderIds []string      `validate:"required,min=1,dive,required"`
+	HalfLife    time.Duration `validate:"gte=0"`
+}
+
+type GetCriteriaAverageModel struct {
+	ProviderId string `validate:"required"`
+	Since      time.Time
+	Until      time.Time
+}
+
+type GetTopProvidersModel struct {
+	Limit       int     `validate:"required,gte=1"`
+	MinCount    int     `validate:"gte=0"`
+	PriorMean   float64 `validate:"gte=0"`
+	PriorWeight float64 `validate:"gte=0"`
+}
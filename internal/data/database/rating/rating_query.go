@@ -0,0 +1,90 @@
+package rating
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// visibleRate
+// Leaves out ratings hidden by a moderator.
+const visibleRate = "hidden_date is null"
+
+// queryFilter
+// Collects where conditions joined with "and" together with their positional arguments.
+type queryFilter struct {
+	conditions []string
+	args       []interface{}
+}
+
+// add
+// Appends a condition such as "rate >=" compared with the next positional argument.
+func (f *queryFilter) add(condition string, arg interface{}) {
+	f.args = append(f.args, arg)
+	f.conditions = append(f.conditions, condition+" "+f.placeholder())
+}
+
+// require
+// Appends a condition without an argument, such as visibleRate.
+func (f *queryFilter) require(condition string) {
+	f.conditions = append(f.conditions, condition)
+}
+
+// arg
+// Appends an argument that is not part of a condition and returns its placeholder.
+func (f *queryFilter) arg(arg interface{}) string {
+	f.args = append(f.args, arg)
+	return f.placeholder()
+}
+
+func (f *queryFilter) placeholder() string {
+	return "$" + strconv.Itoa(len(f.args))
+}
+
+func (f *queryFilter) where() string {
+	if len(f.conditions) == 0 {
+		return "true"
+	}
+
+	return strings.Join(f.conditions, " and ")
+}
+
+// addDateRange
+// Restricts created_date to [since, until), skipping a zero bound.
+func addDateRange(f *queryFilter, since time.Time, until time.Time) {
+	if !since.IsZero() {
+		f.add("created_date >=", since)
+	}
+	if !until.IsZero() {
+		f.add("created_date <", until)
+	}
+}
+
+// rateAggregateColumns
+// Returns the select list scanned by GetRateAggregateResponse.scanDest.
+// DecayedAverage is only computed when halfLife is set, its argument is added to the filter.
+func rateAggregateColumns(f *queryFilter, halfLife time.Duration) string {
+	decayedAverage := "0::float8"
+	if halfLife > 0 {
+		weight := "power(0.5, extract(epoch from current_timestamp - created_date) / " + f.arg(halfLife.Seconds()) + ")"
+		decayedAverage = "coalesce(sum(rate * " + weight + ") / nullif(sum(" + weight + "), 0), 0)"
+	}
+
+	return `count(rate), count(rate) filter (where rate >= 4), sum(rate), avg(rate), min(rate), max(rate), ` + decayedAverage
+}
+
+// ratingCriteriaRows
+// Collects rating_criteria rows as column arrays for a single unnest insert.
+type ratingCriteriaRows struct {
+	ratingIds []int64
+	criteria  []string
+	rates     []int64
+}
+
+func (c *ratingCriteriaRows) add(ratingId int64, criteria map[string]int) {
+	for criterion, rate := range criteria {
+		c.ratingIds = append(c.ratingIds, ratingId)
+		c.criteria = append(c.criteria, criterion)
+		c.rates = append(c.rates, int64(rate))
+	}
+}
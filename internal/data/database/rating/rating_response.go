@@ -1,10 +1,83 @@
 package rating
 
+import "time"
+
 type AddRatingResponse struct {
-	Error error `json:"-"`
+	Id int64
+}
+
+type AddRatesResponse struct {
+	Ids map[string]int64
+}
+
+type FindRatesResponse struct {
+	Ids map[string]int64
 }
 
 type GetAllRatingsResponse struct {
-	Error error `json:"-"`
 	Rates []int
 }
+
+type GetRateAggregateResponse struct {
+	Count          int
+	Positive       int
+	Sum            int
+	Average        float64
+	Min            int
+	Max            int
+	DecayedAverage float64
+}
+
+type GetRateAggregatesResponse struct {
+	Aggregates map[string]GetRateAggregateResponse
+}
+
+type GetCriteriaAverageResponse struct {
+	Averages map[string]float64
+}
+
+type GetRateDistributionResponse struct {
+	Counts map[int]int
+}
+
+type UpdateRatingResponse struct {
+	Id int64
+}
+
+type DeleteRatingResponse struct {
+	Id int64
+}
+
+type HideRatingResponse struct {
+	Id int64
+}
+
+type ListRatesResponse struct {
+	Rates []RateRecord
+}
+
+type RateRecord struct {
+	Id          int64
+	UserName    string
+	ProviderId  string
+	ServiceId   string
+	Rate        int
+	Title       string
+	Comment     string
+	CreatedDate time.Time
+}
+
+type GetTopProvidersResponse struct {
+	Providers []ProviderScore
+}
+
+type ProviderScore struct {
+	ProviderId string
+	Count      int
+	Average    float64
+	Score      float64
+}
+
+func (r *GetRateAggregateResponse) scanDest() []interface{} {
+	return []interface{}{&r.Count, &r.Positive, &r.Sum, &r.Average, &r.Min, &r.Max, &r.DecayedAverage}
+}
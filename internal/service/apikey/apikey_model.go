@@ -0,0 +1,18 @@
+package apikey
+
+type CreateKeyServiceModel struct {
+	Name   string   `validate:"required,max=100"`
+	Scopes []string `validate:"required,min=1,dive,oneof=read write export admin"`
+}
+
+type RevokeKeyServiceModel struct {
+	Id int64 `validate:"required"`
+}
+
+type ListKeysServiceModel struct {
+	IncludeRevoked bool
+}
+
+type AuthenticateServiceModel struct {
+	Key string `validate:"required"`
+}
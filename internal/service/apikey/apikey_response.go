@@ -0,0 +1,50 @@
+package apikey
+
+import "time"
+
+// ApiClientModel
+// The caller identified by an API key.
+type ApiClientModel struct {
+	Id     int64
+	Name   string
+	Scopes []string
+}
+
+// HasScope
+// Reports whether the client was granted scope. The admin scope grants every scope.
+func (c *ApiClientModel) HasScope(scope string) bool {
+	for _, granted := range c.Scopes {
+		if granted == scope || granted == ScopeAdmin {
+			return true
+		}
+	}
+
+	return false
+}
+
+type ApiKeyModel struct {
+	Id           int64
+	Name         string
+	Prefix       string
+	Scopes       []string
+	CreatedDate  time.Time
+	LastUsedDate *time.Time
+	RevokedDate  *time.Time
+}
+
+type CreateKeyServiceResponse struct {
+	Id  int64
+	Key string
+}
+
+type RevokeKeyServiceResponse struct {
+	Info string
+}
+
+type ListKeysServiceResponse struct {
+	Keys []ApiKeyModel
+}
+
+type AuthenticateServiceResponse struct {
+	Client ApiClientModel
+}
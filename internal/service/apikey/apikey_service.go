@@ -0,0 +1,193 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"rating-api/internal/data/database"
+	"rating-api/internal/data/database/apikey"
+	"rating-api/internal/service"
+	"rating-api/internal/util/env"
+	"rating-api/internal/util/logger"
+	"rating-api/internal/util/validator"
+	"strconv"
+	"strings"
+)
+
+type IApiKeyService interface {
+	CreateKey(ctx context.Context, model *CreateKeyServiceModel) (*CreateKeyServiceResponse, error)
+	RevokeKey(ctx context.Context, model *RevokeKeyServiceModel) (*RevokeKeyServiceResponse, error)
+	ListKeys(ctx context.Context, model *ListKeysServiceModel) (*ListKeysServiceResponse, error)
+	Authenticate(ctx context.Context, model *AuthenticateServiceModel) (*AuthenticateServiceResponse, error)
+}
+
+const (
+	ScopeRead   = "read"
+	ScopeWrite  = "write"
+	ScopeExport = "export"
+	ScopeAdmin  = "admin"
+)
+
+// keyPrefix
+// Marks rating API keys, e.g. "rk_1a2b3c4d_...". The part up to the second underscore is stored in clear for listing.
+const keyPrefix = "rk_"
+
+var (
+	ErrKeyNotFound = apikey.ErrKeyNotFound
+	ErrKeyInvalid  = errors.New("API key is invalid or revoked")
+)
+
+type ApiKeyService struct {
+	environment env.IEnvironment
+	loggr       logger.ILogger
+	validatr    validator.IValidator
+	apiKeyDb    apikey.IApiKeyDb
+}
+
+// NewApiKeyService
+// Returns a new ApiKeyService.
+func NewApiKeyService(
+	environment env.IEnvironment,
+	loggr logger.ILogger,
+	validatr validator.IValidator,
+	apiKeyDb apikey.IApiKeyDb,
+) IApiKeyService {
+	service := ApiKeyService{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+	}
+
+	if apiKeyDb != nil {
+		service.apiKeyDb = apiKeyDb
+	} else {
+		service.apiKeyDb = apikey.NewApiKeyDb(loggr, validatr, environment, nil)
+	}
+
+	return &service
+}
+
+// CreateKey
+// Generates a new key and stores its hash. The key itself is only returned here.
+func (s *ApiKeyService) CreateKey(ctx context.Context, model *CreateKeyServiceModel) (*CreateKeyServiceResponse, error) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		s.loggr.Error(modelErr.Error())
+		return nil, service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	prefix, err := randomHex(4)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(24)
+	if err != nil {
+		return nil, err
+	}
+	key := keyPrefix + prefix + "_" + secret
+
+	dbResponse, dbErr := s.apiKeyDb.AddKey(ctx, &apikey.AddKeyModel{
+		Name:    model.Name,
+		Prefix:  keyPrefix + prefix,
+		KeyHash: hashKey(key),
+		Scopes:  model.Scopes,
+	})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
+	}
+
+	return &CreateKeyServiceResponse{Id: dbResponse.Id, Key: key}, nil
+}
+
+func (s *ApiKeyService) RevokeKey(ctx context.Context, model *RevokeKeyServiceModel) (*RevokeKeyServiceResponse, error) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		s.loggr.Error(modelErr.Error())
+		return nil, service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	_, dbErr := s.apiKeyDb.RevokeKey(ctx, &apikey.RevokeKeyModel{Id: model.Id})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
+	}
+
+	return &RevokeKeyServiceResponse{Info: "Revoked API key Id: " + strconv.FormatInt(model.Id, 10)}, nil
+}
+
+func (s *ApiKeyService) ListKeys(ctx context.Context, model *ListKeysServiceModel) (*ListKeysServiceResponse, error) {
+	dbResponse, dbErr := s.apiKeyDb.ListKeys(ctx, &apikey.ListKeysModel{IncludeRevoked: model.IncludeRevoked})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
+	}
+
+	response := ListKeysServiceResponse{Keys: make([]ApiKeyModel, 0, len(dbResponse.Keys))}
+	for _, key := range dbResponse.Keys {
+		response.Keys = append(response.Keys, ApiKeyModel(key))
+	}
+
+	return &response, nil
+}
+
+// Authenticate
+// Returns the client of a valid, unrevoked key and records that the key was used.
+// Returns ErrKeyInvalid for unknown or revoked keys.
+func (s *ApiKeyService) Authenticate(ctx context.Context, model *AuthenticateServiceModel) (*AuthenticateServiceResponse, error) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		return nil, ErrKeyInvalid
+	}
+
+	if !strings.HasPrefix(model.Key, keyPrefix) {
+		return nil, ErrKeyInvalid
+	}
+
+	dbResponse, dbErr := s.apiKeyDb.GetKey(ctx, &apikey.GetKeyModel{KeyHash: hashKey(model.Key)})
+	if errors.Is(dbErr, apikey.ErrKeyNotFound) {
+		return nil, ErrKeyInvalid
+	}
+	if dbErr != nil {
+		return nil, storageError(dbErr)
+	}
+
+	// Failing to record the usage must not fail the request.
+	if _, err := s.apiKeyDb.TouchKey(ctx, &apikey.TouchKeyModel{Id: dbResponse.Key.Id}); err != nil {
+		s.loggr.Warn("Could not record API key usage: " + err.Error())
+	}
+
+	return &AuthenticateServiceResponse{Client: ApiClientModel{
+		Id:     dbResponse.Key.Id,
+		Name:   dbResponse.Key.Name,
+		Scopes: dbResponse.Key.Scopes,
+	}}, nil
+}
+
+// hashKey
+// Keys are long random strings, so a plain SHA-256 is enough to store them safely.
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func randomHex(size int) (string, error) {
+	bytes := make([]byte, size)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
+
+// storageError
+// Marks an error returned by the database with the service error kind it stands for.
+func storageError(err error) error {
+	switch {
+	case errors.Is(err, apikey.ErrKeyNotFound):
+		return service.Wrap(service.ErrNotFound, err)
+	case database.IsUnavailable(err):
+		return service.Wrap(service.ErrUnavailable, err)
+	}
+
+	return err
+}
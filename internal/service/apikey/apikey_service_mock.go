@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/service/apikey/apikey_service.go
+
+// Package apikey is a generated GoMock package.
+package apikey
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIApiKeyService is a mock of IApiKeyService interface.
+type MockIApiKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIApiKeyServiceMockRecorder
+}
+
+// MockIApiKeyServiceMockRecorder is the mock recorder for MockIApiKeyService.
+type MockIApiKeyServiceMockRecorder struct {
+	mock *MockIApiKeyService
+}
+
+// NewMockIApiKeyService creates a new mock instance.
+func NewMockIApiKeyService(ctrl *gomock.Controller) *MockIApiKeyService {
+	mock := &MockIApiKeyService{ctrl: ctrl}
+	mock.recorder = &MockIApiKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIApiKeyService) EXPECT() *MockIApiKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockIApiKeyService) Authenticate(ctx context.Context, model *AuthenticateServiceModel) (*AuthenticateServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, model)
+	ret0, _ := ret[0].(*AuthenticateServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockIApiKeyServiceMockRecorder) Authenticate(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockIApiKeyService)(nil).Authenticate), ctx, model)
+}
+
+// CreateKey mocks base method.
+func (m *MockIApiKeyService) CreateKey(ctx context.Context, model *CreateKeyServiceModel) (*CreateKeyServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKey", ctx, model)
+	ret0, _ := ret[0].(*CreateKeyServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKey indicates an expected call of CreateKey.
+func (mr *MockIApiKeyServiceMockRecorder) CreateKey(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKey", reflect.TypeOf((*MockIApiKeyService)(nil).CreateKey), ctx, model)
+}
+
+// ListKeys mocks base method.
+func (m *MockIApiKeyService) ListKeys(ctx context.Context, model *ListKeysServiceModel) (*ListKeysServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKeys", ctx, model)
+	ret0, _ := ret[0].(*ListKeysServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKeys indicates an expected call of ListKeys.
+func (mr *MockIApiKeyServiceMockRecorder) ListKeys(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeys", reflect.TypeOf((*MockIApiKeyService)(nil).ListKeys), ctx, model)
+}
+
+// RevokeKey mocks base method.
+func (m *MockIApiKeyService) RevokeKey(ctx context.Context, model *RevokeKeyServiceModel) (*RevokeKeyServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeKey", ctx, model)
+	ret0, _ := ret[0].(*RevokeKeyServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeKey indicates an expected call of RevokeKey.
+func (mr *MockIApiKeyServiceMockRecorder) RevokeKey(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeKey", reflect.TypeOf((*MockIApiKeyService)(nil).RevokeKey), ctx, model)
+}
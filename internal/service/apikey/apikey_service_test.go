@@ -0,0 +1,185 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	apiKeyDb "rating-api/internal/data/database/apikey"
+	"rating-api/internal/service"
+	"rating-api/internal/util/env"
+	"rating-api/internal/util/logger"
+	"rating-api/internal/util/validator"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ApiKeyServiceTestSuite struct {
+	suite.Suite
+	apiKeyService   IApiKeyService
+	mockEnvironment *env.MockIEnvironment
+	mockLogger      *logger.MockILogger
+	mockValidator   *validator.MockIValidator
+	mockApiKeyDb    *apiKeyDb.MockIApiKeyDb
+}
+
+// Run suite.
+func TestApiKeyService(t *testing.T) {
+	suite.Run(t, new(ApiKeyServiceTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *ApiKeyServiceTestSuite) SetupTest() {
+	s.T().Log("Setup")
+
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockApiKeyDb = apiKeyDb.NewMockIApiKeyDb(ctrl)
+
+	s.apiKeyService = NewApiKeyService(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockApiKeyDb)
+}
+
+// Runs after each test in the suite.
+func (s *ApiKeyServiceTestSuite) TearDownTest() {
+	s.T().Log("Teardown")
+}
+
+func (s *ApiKeyServiceTestSuite) TestCreateKey_HappyPath_StoresHashOfReturnedKey() {
+	model := CreateKeyServiceModel{Name: "nightly-import", Scopes: []string{ScopeRead, ScopeWrite}}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model)).Return(nil)
+
+	var stored *apiKeyDb.AddKeyModel
+	s.mockApiKeyDb.
+		EXPECT().
+		AddKey(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, model *apiKeyDb.AddKeyModel) (*apiKeyDb.AddKeyResponse, error) {
+			stored = model
+			return &apiKeyDb.AddKeyResponse{Id: 7}, nil
+		})
+
+	response, err := s.apiKeyService.CreateKey(context.Background(), &model)
+
+	s.Nil(err)
+	s.Equal(int64(7), response.Id)
+	s.True(strings.HasPrefix(response.Key, stored.Prefix+"_"))
+	s.Equal(hashKey(response.Key), stored.KeyHash)
+	s.Equal("nightly-import", stored.Name)
+	s.Equal([]string{ScopeRead, ScopeWrite}, stored.Scopes)
+}
+
+func (s *ApiKeyServiceTestSuite) TestCreateKey_UnknownScope_ReturnsValidationError() {
+	model := CreateKeyServiceModel{Name: "nightly-import", Scopes: []string{"delete"}}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model)).Return(errors.New("Scopes[0] must be one of: read, write, export, admin"))
+	s.mockLogger.EXPECT().Error(gomock.Any())
+
+	response, err := s.apiKeyService.CreateKey(context.Background(), &model)
+
+	s.Nil(response)
+	s.ErrorIs(err, service.ErrValidation)
+}
+
+func (s *ApiKeyServiceTestSuite) TestAuthenticate_ValidKey_ReturnsClientAndTouchesKey() {
+	key := "rk_1a2b3c4d_secret"
+	model := AuthenticateServiceModel{Key: key}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model)).Return(nil)
+
+	s.mockApiKeyDb.
+		EXPECT().
+		GetKey(gomock.Any(), gomock.Eq(&apiKeyDb.GetKeyModel{KeyHash: hashKey(key)})).
+		Return(&apiKeyDb.GetKeyResponse{Key: apiKeyDb.ApiKeyRecord{Id: 7, Name: "nightly-import", Scopes: []string{ScopeWrite}}}, nil)
+
+	s.mockApiKeyDb.
+		EXPECT().
+		TouchKey(gomock.Any(), gomock.Eq(&apiKeyDb.TouchKeyModel{Id: 7})).
+		Return(&apiKeyDb.TouchKeyResponse{Touched: true}, nil)
+
+	response, err := s.apiKeyService.Authenticate(context.Background(), &model)
+
+	s.Nil(err)
+	s.Equal(ApiClientModel{Id: 7, Name: "nightly-import", Scopes: []string{ScopeWrite}}, response.Client)
+}
+
+func (s *ApiKeyServiceTestSuite) TestAuthenticate_TouchFails_StillReturnsClient() {
+	model := AuthenticateServiceModel{Key: "rk_1a2b3c4d_secret"}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+
+	s.mockApiKeyDb.
+		EXPECT().
+		GetKey(gomock.Any(), gomock.Any()).
+		Return(&apiKeyDb.GetKeyResponse{Key: apiKeyDb.ApiKeyRecord{Id: 7, Name: "nightly-import"}}, nil)
+
+	s.mockApiKeyDb.
+		EXPECT().
+		TouchKey(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("an error occurred"))
+
+	s.mockLogger.EXPECT().Warn(gomock.Any())
+
+	response, err := s.apiKeyService.Authenticate(context.Background(), &model)
+
+	s.Nil(err)
+	s.Equal(int64(7), response.Client.Id)
+}
+
+func (s *ApiKeyServiceTestSuite) TestAuthenticate_UnknownKey_ReturnsErrKeyInvalid() {
+	model := AuthenticateServiceModel{Key: "rk_1a2b3c4d_secret"}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+
+	s.mockApiKeyDb.
+		EXPECT().
+		GetKey(gomock.Any(), gomock.Any()).
+		Return(nil, apiKeyDb.ErrKeyNotFound)
+
+	response, err := s.apiKeyService.Authenticate(context.Background(), &model)
+
+	s.Nil(response)
+	s.ErrorIs(err, ErrKeyInvalid)
+}
+
+func (s *ApiKeyServiceTestSuite) TestAuthenticate_ForeignKeyFormat_ReturnsErrKeyInvalid() {
+	model := AuthenticateServiceModel{Key: "not-a-rating-key"}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+
+	response, err := s.apiKeyService.Authenticate(context.Background(), &model)
+
+	s.Nil(response)
+	s.ErrorIs(err, ErrKeyInvalid)
+}
+
+func (s *ApiKeyServiceTestSuite) TestRevokeKey_UnknownKey_ReturnsErrNotFound() {
+	model := RevokeKeyServiceModel{Id: 404}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model)).Return(nil)
+
+	s.mockApiKeyDb.
+		EXPECT().
+		RevokeKey(gomock.Any(), gomock.Eq(&apiKeyDb.RevokeKeyModel{Id: 404})).
+		Return(nil, fmt.Errorf("%w for Id: 404", apiKeyDb.ErrKeyNotFound))
+
+	response, err := s.apiKeyService.RevokeKey(context.Background(), &model)
+
+	s.Nil(response)
+	s.ErrorIs(err, ErrKeyNotFound)
+	s.ErrorIs(err, service.ErrNotFound)
+}
+
+func (s *ApiKeyServiceTestSuite) TestHasScope_Admin_GrantsEveryScope() {
+	admin := ApiClientModel{Scopes: []string{ScopeAdmin}}
+	reader := ApiClientModel{Scopes: []string{ScopeRead}}
+
+	s.True(admin.HasScope(ScopeExport))
+	s.True(reader.HasScope(ScopeRead))
+	s.False(reader.HasScope(ScopeWrite))
+}
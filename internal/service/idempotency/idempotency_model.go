@@ -0,0 +1,17 @@
+package idempotency
+
+type BeginRequestServiceModel struct {
+	Scope   string `validate:"required,max=64"`
+	Key     string `validate:"required,max=255"`
+	Subject string `validate:"max=255"`
+	Body    []byte
+}
+
+type FinishRequestServiceModel struct {
+	Scope       string `validate:"required,max=64"`
+	Subject     string `validate:"max=255"`
+	Key         string `validate:"required,max=255"`
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
@@ -0,0 +1,17 @@
+package idempotency
+
+// StoredResponseModel
+// The response of the first request made with a key.
+type StoredResponseModel struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+type BeginRequestServiceResponse struct {
+	Replay *StoredResponseModel
+}
+
+type FinishRequestServiceResponse struct {
+	Stored bool
+}
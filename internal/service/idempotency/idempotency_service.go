@@ -0,0 +1,152 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"rating-api/internal/data/database"
+	"rating-api/internal/data/database/idempotency"
+	"rating-api/internal/service"
+	"rating-api/internal/util/env"
+	"rating-api/internal/util/logger"
+	"rating-api/internal/util/validator"
+	"time"
+)
+
+type IIdempotencyService interface {
+	Begin(ctx context.Context, model *BeginRequestServiceModel) (*BeginRequestServiceResponse, error)
+	Finish(ctx context.Context, model *FinishRequestServiceModel) (*FinishRequestServiceResponse, error)
+}
+
+const (
+	defaultKeyTtl       = 24 * time.Hour
+	defaultLeaseTimeout = time.Minute
+)
+
+var (
+	ErrKeyReused         = errors.New("Idempotency-Key was already used with a different request body")
+	ErrRequestInProgress = errors.New("A request with this Idempotency-Key is still in progress")
+)
+
+type IdempotencyService struct {
+	environment   env.IEnvironment
+	loggr         logger.ILogger
+	validatr      validator.IValidator
+	idempotencyDb idempotency.IIdempotencyDb
+}
+
+// NewIdempotencyService
+// Returns a new IdempotencyService.
+func NewIdempotencyService(
+	environment env.IEnvironment,
+	loggr logger.ILogger,
+	validatr validator.IValidator,
+	idempotencyDb idempotency.IIdempotencyDb,
+) IIdempotencyService {
+	service := IdempotencyService{
+		environment: environment,
+		loggr:       loggr,
+		validatr:    validatr,
+	}
+
+	if idempotencyDb != nil {
+		service.idempotencyDb = idempotencyDb
+	} else {
+		service.idempotencyDb = idempotency.NewIdempotencyDb(loggr, validatr, environment, nil)
+	}
+
+	return &service
+}
+
+// Begin
+// Reserves the key for the request, or returns the stored response of an earlier request with the same key and body.
+// Returns ErrKeyReused when the body differs and ErrRequestInProgress while the earlier request has not finished
+// and its lease has not run out.
+func (s *IdempotencyService) Begin(ctx context.Context, model *BeginRequestServiceModel) (*BeginRequestServiceResponse, error) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		s.loggr.Error(modelErr.Error())
+		return nil, service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	hash := sha256.Sum256(model.Body)
+	requestHash := hex.EncodeToString(hash[:])
+
+	// Keys are reserved per subject, so callers never see or replay each other's keys.
+	reserved, err := s.idempotencyDb.Reserve(ctx, &idempotency.ReserveKeyModel{
+		Scope:       model.Scope,
+		Subject:     model.Subject,
+		Key:         model.Key,
+		RequestHash: requestHash,
+		Ttl:         s.environment.GetDuration(env.IdempotencyKeyTtl, defaultKeyTtl),
+		Lease:       s.environment.GetDuration(env.IdempotencyLeaseTimeout, defaultLeaseTimeout),
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+
+	if reserved.Reserved {
+		return &BeginRequestServiceResponse{}, nil
+	}
+
+	record := reserved.Record
+	if record.RequestHash != requestHash {
+		return nil, service.Wrap(service.ErrValidation, ErrKeyReused)
+	}
+	if record.StatusCode == nil {
+		return nil, service.Wrap(service.ErrDuplicate, ErrRequestInProgress)
+	}
+
+	return &BeginRequestServiceResponse{
+		Replay: &StoredResponseModel{
+			StatusCode:  *record.StatusCode,
+			ContentType: record.ContentType,
+			Body:        record.Body,
+		},
+	}, nil
+}
+
+// Finish
+// Stores the response for replay. Server errors are not stored, the key is released so the client can retry with it.
+func (s *IdempotencyService) Finish(ctx context.Context, model *FinishRequestServiceModel) (*FinishRequestServiceResponse, error) {
+	modelErr := s.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		s.loggr.Error(modelErr.Error())
+		return nil, service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	if model.StatusCode >= http.StatusInternalServerError {
+		_, err := s.idempotencyDb.Release(ctx, &idempotency.ReleaseKeyModel{Scope: model.Scope, Subject: model.Subject, Key: model.Key})
+		if err != nil {
+			return nil, storageError(err)
+		}
+
+		return &FinishRequestServiceResponse{}, nil
+	}
+
+	completed, err := s.idempotencyDb.Complete(ctx, &idempotency.CompleteKeyModel{
+		Scope:       model.Scope,
+		Subject:     model.Subject,
+		Key:         model.Key,
+		StatusCode:  model.StatusCode,
+		ContentType: model.ContentType,
+		Body:        model.Body,
+	})
+	if err != nil {
+		return nil, storageError(err)
+	}
+
+	return &FinishRequestServiceResponse{Stored: completed.Completed}, nil
+}
+
+// storageError
+// Marks an error returned by the database as unavailable storage when it is one.
+func storageError(err error) error {
+	if database.IsUnavailable(err) {
+		return service.Wrap(service.ErrUnavailable, err)
+	}
+
+	return err
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/service/idempotency/idempotency_service.go
+
+// Package idempotency is a generated GoMock package.
+package idempotency
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIIdempotencyService is a mock of IIdempotencyService interface.
+type MockIIdempotencyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIdempotencyServiceMockRecorder
+}
+
+// MockIIdempotencyServiceMockRecorder is the mock recorder for MockIIdempotencyService.
+type MockIIdempotencyServiceMockRecorder struct {
+	mock *MockIIdempotencyService
+}
+
+// NewMockIIdempotencyService creates a new mock instance.
+func NewMockIIdempotencyService(ctrl *gomock.Controller) *MockIIdempotencyService {
+	mock := &MockIIdempotencyService{ctrl: ctrl}
+	mock.recorder = &MockIIdempotencyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIdempotencyService) EXPECT() *MockIIdempotencyServiceMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockIIdempotencyService) Begin(ctx context.Context, model *BeginRequestServiceModel) (*BeginRequestServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin", ctx, model)
+	ret0, _ := ret[0].(*BeginRequestServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockIIdempotencyServiceMockRecorder) Begin(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockIIdempotencyService)(nil).Begin), ctx, model)
+}
+
+// Finish mocks base method.
+func (m *MockIIdempotencyService) Finish(ctx context.Context, model *FinishRequestServiceModel) (*FinishRequestServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, model)
+	ret0, _ := ret[0].(*FinishRequestServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockIIdempotencyServiceMockRecorder) Finish(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockIIdempotencyService)(nil).Finish), ctx, model)
+}
@@ -0,0 +1,271 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	idempotencyDb "rating-api/internal/data/database/idempotency"
+	"rating-api/internal/util/env"
+	"rating-api/internal/util/logger"
+	"rating-api/internal/util/validator"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+)
+
+// sha256 of `{"Rate":5}`.
+const requestHash = "5031a55283b2702d2b24145d38f5bedc1eb1aecc0ad6302390451d0b029abeaa"
+
+type IdempotencyServiceTestSuite struct {
+	suite.Suite
+	idempotencyService IIdempotencyService
+	mockEnvironment    *env.MockIEnvironment
+	mockLogger         *logger.MockILogger
+	mockValidator      *validator.MockIValidator
+	mockIdempotencyDb  *idempotencyDb.MockIIdempotencyDb
+}
+
+// Run suite.
+func TestIdempotencyService(t *testing.T) {
+	suite.Run(t, new(IdempotencyServiceTestSuite))
+}
+
+// Runs before each test in the suite.
+func (s *IdempotencyServiceTestSuite) SetupTest() {
+	s.T().Log("Setup")
+
+	ctrl := gomock.NewController(s.T())
+	defer ctrl.Finish()
+
+	s.mockEnvironment = env.NewMockIEnvironment(ctrl)
+	s.mockLogger = logger.NewMockILogger(ctrl)
+	s.mockValidator = validator.NewMockIValidator(ctrl)
+	s.mockIdempotencyDb = idempotencyDb.NewMockIIdempotencyDb(ctrl)
+
+	s.idempotencyService = NewIdempotencyService(s.mockEnvironment, s.mockLogger, s.mockValidator, s.mockIdempotencyDb)
+}
+
+// Runs after each test in the suite.
+func (s *IdempotencyServiceTestSuite) TearDownTest() {
+	s.T().Log("Teardown")
+}
+
+func (s *IdempotencyServiceTestSuite) TestBegin_NewKey_Reserves() {
+	model := BeginRequestServiceModel{Scope: "POST /api/v1/rating/add", Key: "k-1", Body: []byte(`{"Rate":5}`)}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model)).Return(nil)
+	s.mockEnvironment.EXPECT().GetDuration(env.IdempotencyKeyTtl, gomock.Any()).Return(time.Hour)
+	s.mockEnvironment.EXPECT().GetDuration(env.IdempotencyLeaseTimeout, gomock.Any()).Return(time.Minute)
+
+	s.mockIdempotencyDb.
+		EXPECT().
+		Reserve(gomock.Any(), gomock.Eq(&idempotencyDb.ReserveKeyModel{
+			Scope:       "POST /api/v1/rating/add",
+			Key:         "k-1",
+			RequestHash: requestHash,
+			Ttl:         time.Hour,
+			Lease:       time.Minute,
+		})).
+		Return(&idempotencyDb.ReserveKeyResponse{Reserved: true}, nil)
+
+	response, err := s.idempotencyService.Begin(context.Background(), &model)
+
+	s.Nil(err)
+	s.Nil(response.Replay)
+}
+
+func (s *IdempotencyServiceTestSuite) TestBegin_SameKeyOfTwoSubjects_ReservesEachOnItsOwn() {
+	first := BeginRequestServiceModel{Scope: "POST /api/v1/rating/add", Key: "k-1", Subject: "u-1", Body: []byte(`{"Rate":5}`)}
+	second := BeginRequestServiceModel{Scope: "POST /api/v1/rating/add", Key: "k-1", Subject: "u-2", Body: []byte(`{"Rate":1}`)}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil).Times(2)
+	s.mockEnvironment.EXPECT().GetDuration(env.IdempotencyKeyTtl, gomock.Any()).Return(time.Hour).Times(2)
+	s.mockEnvironment.EXPECT().GetDuration(env.IdempotencyLeaseTimeout, gomock.Any()).Return(time.Minute).Times(2)
+
+	s.mockIdempotencyDb.
+		EXPECT().
+		Reserve(gomock.Any(), gomock.Eq(&idempotencyDb.ReserveKeyModel{
+			Scope:       "POST /api/v1/rating/add",
+			Subject:     "u-1",
+			Key:         "k-1",
+			RequestHash: requestHash,
+			Ttl:         time.Hour,
+			Lease:       time.Minute,
+		})).
+		Return(&idempotencyDb.ReserveKeyResponse{Reserved: true}, nil)
+	s.mockIdempotencyDb.
+		EXPECT().
+		Reserve(gomock.Any(), gomock.Eq(&idempotencyDb.ReserveKeyModel{
+			Scope:       "POST /api/v1/rating/add",
+			Subject:     "u-2",
+			Key:         "k-1",
+			RequestHash: "816dc4c1ed5d24cb3d8c2d5a1e1f5206946115d5d5a003108c18bc11daf7f3e6",
+			Ttl:         time.Hour,
+			Lease:       time.Minute,
+		})).
+		Return(&idempotencyDb.ReserveKeyResponse{Reserved: true}, nil)
+
+	firstResponse, firstErr := s.idempotencyService.Begin(context.Background(), &first)
+	secondResponse, secondErr := s.idempotencyService.Begin(context.Background(), &second)
+
+	s.Nil(firstErr)
+	s.Nil(firstResponse.Replay)
+	s.Nil(secondErr)
+	s.Nil(secondResponse.Replay)
+}
+
+func (s *IdempotencyServiceTestSuite) TestFinish_WithSubject_StoresResponseOfThatSubject() {
+	model := FinishRequestServiceModel{
+		Scope:       "POST /api/v1/rating/add",
+		Subject:     "u-2",
+		Key:         "k-1",
+		StatusCode:  http.StatusOK,
+		ContentType: "application/json; charset=utf-8",
+		Body:        []byte(`{"Data":{"Id":2},"Message":"Success"}`),
+	}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model)).Return(nil)
+
+	s.mockIdempotencyDb.
+		EXPECT().
+		Complete(gomock.Any(), gomock.Eq(&idempotencyDb.CompleteKeyModel{
+			Scope:       "POST /api/v1/rating/add",
+			Subject:     "u-2",
+			Key:         "k-1",
+			StatusCode:  http.StatusOK,
+			ContentType: "application/json; charset=utf-8",
+			Body:        []byte(`{"Data":{"Id":2},"Message":"Success"}`),
+		})).
+		Return(&idempotencyDb.CompleteKeyResponse{Completed: true}, nil)
+
+	response, err := s.idempotencyService.Finish(context.Background(), &model)
+
+	s.Nil(err)
+	s.True(response.Stored)
+}
+
+func (s *IdempotencyServiceTestSuite) TestBegin_CompletedKey_ReturnsStoredResponse() {
+	model := BeginRequestServiceModel{Scope: "POST /api/v1/rating/add", Key: "k-1", Body: []byte(`{"Rate":5}`)}
+	statusCode := http.StatusOK
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockEnvironment.EXPECT().GetDuration(env.IdempotencyKeyTtl, gomock.Any()).Return(time.Hour)
+	s.mockEnvironment.EXPECT().GetDuration(env.IdempotencyLeaseTimeout, gomock.Any()).Return(time.Minute)
+
+	s.mockIdempotencyDb.
+		EXPECT().
+		Reserve(gomock.Any(), gomock.Any()).
+		Return(&idempotencyDb.ReserveKeyResponse{Record: &idempotencyDb.IdempotencyRecord{
+			RequestHash: requestHash,
+			StatusCode:  &statusCode,
+			ContentType: "application/json; charset=utf-8",
+			Body:        []byte(`{"Data":{"Id":1},"Message":"Success"}`),
+		}}, nil)
+
+	response, err := s.idempotencyService.Begin(context.Background(), &model)
+
+	s.Nil(err)
+	s.Equal(&StoredResponseModel{
+		StatusCode:  http.StatusOK,
+		ContentType: "application/json; charset=utf-8",
+		Body:        []byte(`{"Data":{"Id":1},"Message":"Success"}`),
+	}, response.Replay)
+}
+
+func (s *IdempotencyServiceTestSuite) TestBegin_DifferentBody_ReturnsErrKeyReused() {
+	model := BeginRequestServiceModel{Scope: "POST /api/v1/rating/add", Key: "k-1", Body: []byte(`{"Rate":4}`)}
+	statusCode := http.StatusOK
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockEnvironment.EXPECT().GetDuration(env.IdempotencyKeyTtl, gomock.Any()).Return(time.Hour)
+	s.mockEnvironment.EXPECT().GetDuration(env.IdempotencyLeaseTimeout, gomock.Any()).Return(time.Minute)
+
+	s.mockIdempotencyDb.
+		EXPECT().
+		Reserve(gomock.Any(), gomock.Any()).
+		Return(&idempotencyDb.ReserveKeyResponse{Record: &idempotencyDb.IdempotencyRecord{
+			RequestHash: requestHash,
+			StatusCode:  &statusCode,
+		}}, nil)
+
+	response, err := s.idempotencyService.Begin(context.Background(), &model)
+
+	s.Nil(response)
+	s.ErrorIs(err, ErrKeyReused)
+}
+
+func (s *IdempotencyServiceTestSuite) TestBegin_UnfinishedKey_ReturnsErrRequestInProgress() {
+	model := BeginRequestServiceModel{Scope: "POST /api/v1/rating/add", Key: "k-1", Body: []byte(`{"Rate":5}`)}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+	s.mockEnvironment.EXPECT().GetDuration(env.IdempotencyKeyTtl, gomock.Any()).Return(time.Hour)
+	s.mockEnvironment.EXPECT().GetDuration(env.IdempotencyLeaseTimeout, gomock.Any()).Return(time.Minute)
+
+	s.mockIdempotencyDb.
+		EXPECT().
+		Reserve(gomock.Any(), gomock.Any()).
+		Return(&idempotencyDb.ReserveKeyResponse{Record: &idempotencyDb.IdempotencyRecord{RequestHash: requestHash}}, nil)
+
+	response, err := s.idempotencyService.Begin(context.Background(), &model)
+
+	s.Nil(response)
+	s.ErrorIs(err, ErrRequestInProgress)
+}
+
+func (s *IdempotencyServiceTestSuite) TestBegin_InvalidModel_ReturnsError() {
+	model := BeginRequestServiceModel{Scope: "POST /api/v1/rating/add"}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model)).Return(errors.New("Key is required"))
+	s.mockLogger.EXPECT().Error(gomock.Any())
+
+	response, err := s.idempotencyService.Begin(context.Background(), &model)
+
+	s.Nil(response)
+	s.Error(err)
+}
+
+func (s *IdempotencyServiceTestSuite) TestFinish_Success_StoresResponse() {
+	model := FinishRequestServiceModel{
+		Scope:       "POST /api/v1/rating/add",
+		Key:         "k-1",
+		StatusCode:  http.StatusOK,
+		ContentType: "application/json; charset=utf-8",
+		Body:        []byte(`{"Data":{"Id":1},"Message":"Success"}`),
+	}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model)).Return(nil)
+
+	s.mockIdempotencyDb.
+		EXPECT().
+		Complete(gomock.Any(), gomock.Eq(&idempotencyDb.CompleteKeyModel{
+			Scope:       "POST /api/v1/rating/add",
+			Key:         "k-1",
+			StatusCode:  http.StatusOK,
+			ContentType: "application/json; charset=utf-8",
+			Body:        []byte(`{"Data":{"Id":1},"Message":"Success"}`),
+		})).
+		Return(&idempotencyDb.CompleteKeyResponse{Completed: true}, nil)
+
+	response, err := s.idempotencyService.Finish(context.Background(), &model)
+
+	s.Nil(err)
+	s.True(response.Stored)
+}
+
+func (s *IdempotencyServiceTestSuite) TestFinish_ServerError_ReleasesKey() {
+	model := FinishRequestServiceModel{Scope: "POST /api/v1/rating/add", Key: "k-1", StatusCode: http.StatusInternalServerError}
+
+	s.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model)).Return(nil)
+
+	s.mockIdempotencyDb.
+		EXPECT().
+		Release(gomock.Any(), gomock.Eq(&idempotencyDb.ReleaseKeyModel{Scope: "POST /api/v1/rating/add", Key: "k-1"})).
+		Return(&idempotencyDb.ReleaseKeyResponse{Released: true}, nil)
+
+	response, err := s.idempotencyService.Finish(context.Background(), &model)
+
+	s.Nil(err)
+	s.False(response.Stored)
+}
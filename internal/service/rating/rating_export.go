@@ -0,0 +1,114 @@
+package rating
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"rating-api/internal/data/database/rating"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ExportFormatCsv    = "csv"
+	ExportFormatNdjson = "ndjson"
+)
+
+// ratingEncoder
+// Writes exported ratings in one format. Output may be buffered until Flush.
+type ratingEncoder interface {
+	Encode(record *rating.RateRecord) error
+	Flush() error
+}
+
+func newRatingEncoder(format string, w io.Writer) ratingEncoder {
+	if format == ExportFormatNdjson {
+		buffer := bufio.NewWriter(w)
+		return &ndjsonRatingEncoder{buffer: buffer, encoder: json.NewEncoder(buffer)}
+	}
+
+	return &csvRatingEncoder{writer: csv.NewWriter(w)}
+}
+
+type csvRatingEncoder struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (e *csvRatingEncoder) Encode(record *rating.RateRecord) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	var createdDate string
+	if !record.CreatedDate.IsZero() {
+		createdDate = record.CreatedDate.Format(time.RFC3339)
+	}
+
+	return e.writer.Write([]string{
+		strconv.FormatInt(record.Id, 10),
+		escapeCsvFormula(record.UserName),
+		escapeCsvFormula(record.ProviderId),
+		escapeCsvFormula(record.ServiceId),
+		strconv.Itoa(record.Rate),
+		escapeCsvFormula(record.Title),
+		escapeCsvFormula(record.Comment),
+		createdDate,
+	})
+}
+
+// Flush
+// Writes out buffered rows, and the header alone when there were no rows.
+func (e *csvRatingEncoder) Flush() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *csvRatingEncoder) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+
+	e.headerWritten = true
+	return e.writer.Write([]string{"id", "username", "provider_id", "service_id", "rate", "title", "comment", "created_date"})
+}
+
+// escapeCsvFormula
+// Prefixes client written text that a spreadsheet would run as a formula with a quote, so it is shown as text.
+// Text already starting with quotes before such a character gets one more, so the import can undo every escape exactly.
+func escapeCsvFormula(value string) string {
+	unquoted := strings.TrimLeft(value, "'")
+	if unquoted != "" && strings.ContainsRune("=+-@\t\r", rune(unquoted[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+type ndjsonRatingEncoder struct {
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (e *ndjsonRatingEncoder) Encode(record *rating.RateRecord) error {
+	return e.encoder.Encode(ExportRatingModel{
+		Id:          record.Id,
+		UserName:    record.UserName,
+		ProviderId:  record.ProviderId,
+		ServiceId:   record.ServiceId,
+		Rate:        record.Rate,
+		Title:       record.Title,
+		Comment:     record.Comment,
+		CreatedDate: record.CreatedDate,
+	})
+}
+
+func (e *ndjsonRatingEncoder) Flush() error {
+	return e.buffer.Flush()
+}
@@ -1,12 +1,76 @@
 package rating
 
+import "time"
+
 type SendRatingServiceModel struct {
-	UserName   string `validate:"required"`
-	ProviderId string `validate:"required"`
-	ServiceId  string `validate:"required"`
-	Rate       int    `validate:"required,gte=1,lte=5"`
+	UserName   string         `validate:"required,max=36"`
+	ProviderId string         `validate:"required,max=32"`
+	ServiceId  string         `validate:"required,max=32"`
+	Rate       int            `validate:"required,gte=1,lte=5"`
+	Title      string         `validate:"omitempty,max=100"`
+	Comment    string         `validate:"omitempty,max=2000"`
+	Criteria   map[string]int `validate:"omitempty,dive,keys,required,max=32,endkeys,gte=1,lte=5"`
 }
 
 type GetAverageRatingServiceModel struct {
 	ProviderId string `validate:"required"`
+	Mode       string `validate:"omitempty,oneof=mean bayesian wilson decay"`
+	Since      time.Time
+	Until      time.Time
+	Window     string
+}
+
+type GetRatingDistributionServiceModel struct {
+	ProviderId string `validate:"required"`
+}
+
+type SendRatingsBulkServiceModel struct {
+	Ratings []SendRatingServiceModel
+	DryRun  bool
+}
+
+type ExportRatingsServiceModel struct {
+	ProviderId string
+	Format     string `validate:"required,oneof=csv ndjson"`
+	Since      time.Time
+}
+
+type UpdateRatingServiceModel struct {
+	UserName  string `validate:"required"`
+	ServiceId string `validate:"required"`
+	Rate      int    `validate:"required,gte=1,lte=5"`
+	Title     string `validate:"omitempty,max=100"`
+	Comment   string `validate:"omitempty,max=2000"`
+}
+
+type DeleteRatingServiceModel struct {
+	UserName  string `validate:"required"`
+	ServiceId string `validate:"required"`
+	AnyOwner  bool
+}
+
+type HideRatingServiceModel struct {
+	ServiceId string `validate:"required"`
+	Hidden    bool
+}
+
+type ListRatingsServiceModel struct {
+	ProviderId string `validate:"required"`
+	Cursor     string
+	Limit      int `validate:"gte=0,lte=100"`
+	MinRate    int `validate:"omitempty,gte=1,lte=5"`
+	MaxRate    int `validate:"omitempty,gte=1,lte=5"`
+	Since      time.Time
+	Until      time.Time
+}
+
+type GetAverageRatingBatchServiceModel struct {
+	ProviderIds []string `validate:"required,min=1,max=100,dive,required"`
+	Mode        string   `validate:"omitempty,oneof=mean bayesian wilson decay"`
+}
+
+type GetTopRatedProvidersServiceModel struct {
+	Limit    int    `validate:"gte=0,lte=100"`
+	MinCount int    `validate:"gte=0"`
+	Mode     string `validate:"omitempty,oneof=mean bayesian"`
 }
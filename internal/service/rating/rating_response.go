@@ -1,16 +1,92 @@
 package rating
 
+import "time"
+
 type SendRatingServiceResponse struct {
-	Error error `json:"-"`
-	Info  string
+	Info string
+}
+
+type SendRatingsBulkServiceResponse struct {
+	Inserted int
+	Results  []BulkRatingResultModel
+}
+
+type BulkRatingResultModel struct {
+	Index     int
+	ServiceId string
+	Status    string
+	Error     string
+}
+
+type UpdateRatingServiceResponse struct {
+	Info string
+}
+
+type DeleteRatingServiceResponse struct {
+	Info string
+}
+
+type HideRatingServiceResponse struct {
+	Info string
 }
 
 type GetAverageRatingServiceResponse struct {
-	Error         error `json:"-"`
 	AverageRating AverageRatingModel
 }
 
+type GetAverageRatingBatchServiceResponse struct {
+	AverageRatings []AverageRatingModel
+}
+
+type GetTopRatedProvidersServiceResponse struct {
+	Providers []AverageRatingModel
+}
+
 type AverageRatingModel struct {
+	ProviderId       string
+	AverageRate      float64
+	Mode             string
+	RatingCount      int
+	CriteriaAverages map[string]float64
+}
+
+type GetRatingDistributionServiceResponse struct {
+	Distribution RatingDistributionModel
+}
+
+type RatingDistributionModel struct {
+	ProviderId string
+	TotalCount int
+	Buckets    []RatingBucketModel
+}
+
+type RatingBucketModel struct {
+	Rate       int
+	Count      int
+	Percentage float64
+}
+
+type ListRatingsServiceResponse struct {
+	Ratings    []RatingModel
+	NextCursor string
+}
+
+type ExportRatingModel struct {
+	Id          int64
+	UserName    string
 	ProviderId  string
-	AverageRate float64
+	ServiceId   string
+	Rate        int
+	Title       string
+	Comment     string
+	CreatedDate time.Time
+}
+
+type RatingModel struct {
+	UserName    string
+	ServiceId   string
+	Rate        int
+	Title       string
+	Comment     string
+	CreatedDate time.Time
 }
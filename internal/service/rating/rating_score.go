@@ -0,0 +1,73 @@
+package rating
+
+import (
+	"math"
+	"rating-api/internal/data/database/rating"
+)
+
+const (
+	AverageModeMean     = "mean"
+	AverageModeBayesian = "bayesian"
+	AverageModeWilson   = "wilson"
+	AverageModeDecay    = "decay"
+)
+
+// wilsonZ is the z-score of a 95% confidence level.
+const wilsonZ = 1.96
+
+type bayesianPrior struct {
+	mean   float64
+	weight float64
+}
+
+// bayesianAverage
+// Returns the average pulled towards the prior mean as if weight extra ratings of prior mean were given,
+// so providers with few ratings do not outrank ones with many.
+func bayesianAverage(sum int, count int, prior bayesianPrior) float64 {
+	if float64(count)+prior.weight == 0 {
+		return 0
+	}
+
+	return (prior.weight*prior.mean + float64(sum)) / (prior.weight + float64(count))
+}
+
+// wilsonLowerBound
+// Returns the lower bound of the Wilson score interval for the share of positive ratings,
+// a value between 0 and 1.
+func wilsonLowerBound(positive int, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+
+	n := float64(count)
+	p := float64(positive) / n
+	z2 := wilsonZ * wilsonZ
+
+	return (p + z2/(2*n) - wilsonZ*math.Sqrt((p*(1-p)+z2/(4*n))/n)) / (1 + z2/n)
+}
+
+// wilsonRate
+// Maps the Wilson lower bound onto the 1 to 5 star scale of the other modes, 1 when no rating is positive for sure
+// and 5 when every one is. Providers without ratings get 0 like in the other modes.
+func wilsonRate(positive int, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+
+	return 1 + 4*wilsonLowerBound(positive, count)
+}
+
+// averageRate
+// Returns the score of the aggregated ratings for the given average mode.
+func averageRate(mode string, aggregate *rating.GetRateAggregateResponse, prior bayesianPrior) float64 {
+	switch mode {
+	case AverageModeBayesian:
+		return bayesianAverage(aggregate.Sum, aggregate.Count, prior)
+	case AverageModeWilson:
+		return wilsonRate(aggregate.Positive, aggregate.Count)
+	case AverageModeDecay:
+		return aggregate.DecayedAverage
+	default:
+		return aggregate.Average
+	}
+}
@@ -1,18 +1,57 @@
 package rating
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
+	"rating-api/internal/data/database"
 	"rating-api/internal/data/database/rating"
+	"rating-api/internal/service"
 	"rating-api/internal/util/env"
 	"rating-api/internal/util/logger"
+	"rating-api/internal/util/metrics"
 	"rating-api/internal/util/validator"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type IRatingService interface {
-	SendRating(ch chan *SendRatingServiceResponse, model *SendRatingServiceModel)
-	GetAverageRating(ch chan *GetAverageRatingServiceResponse, model *GetAverageRatingServiceModel)
+	SendRating(ctx context.Context, model *SendRatingServiceModel) (*SendRatingServiceResponse, error)
+	SendRatingsBulk(ctx context.Context, model *SendRatingsBulkServiceModel) (*SendRatingsBulkServiceResponse, error)
+	UpdateRating(ctx context.Context, model *UpdateRatingServiceModel) (*UpdateRatingServiceResponse, error)
+	DeleteRating(ctx context.Context, model *DeleteRatingServiceModel) (*DeleteRatingServiceResponse, error)
+	HideRating(ctx context.Context, model *HideRatingServiceModel) (*HideRatingServiceResponse, error)
+	GetAverageRating(ctx context.Context, model *GetAverageRatingServiceModel) (*GetAverageRatingServiceResponse, error)
+	GetAverageRatingBatch(ctx context.Context, model *GetAverageRatingBatchServiceModel) (*GetAverageRatingBatchServiceResponse, error)
+	GetTopRatedProviders(ctx context.Context, model *GetTopRatedProvidersServiceModel) (*GetTopRatedProvidersServiceResponse, error)
+	GetRatingDistribution(ctx context.Context, model *GetRatingDistributionServiceModel) (*GetRatingDistributionServiceResponse, error)
+	ListRatings(ctx context.Context, model *ListRatingsServiceModel) (*ListRatingsServiceResponse, error)
+	ExportRatings(ctx context.Context, model *ExportRatingsServiceModel, w io.Writer) error
 }
 
+const (
+	BulkStatusInserted  = "inserted"
+	BulkStatusDuplicate = "duplicate"
+	BulkStatusInvalid   = "validation_error"
+	BulkStatusValid     = "valid"
+)
+
+const (
+	minRate = 1
+	maxRate = 5
+
+	defaultListLimit = 20
+	defaultTopLimit  = 10
+)
+
+var (
+	ErrRatingNotFound  = rating.ErrRateNotFound
+	ErrRatingNotOwned  = rating.ErrRateNotOwned
+	ErrRatingDuplicate = rating.ErrRateDuplicate
+)
+
 type RatingService struct {
 	environment env.IEnvironment
 	loggr       logger.ILogger
@@ -37,73 +76,581 @@ func NewRatingService(
 	if ratingDb != nil {
 		service.ratingDb = ratingDb
 	} else {
-		service.ratingDb = rating.NewRatingDb(loggr, validatr, environment)
+		service.ratingDb = rating.NewRatingDb(loggr, validatr, environment, nil)
 	}
 
 	return &service
 }
 
-func (r *RatingService) SendRating(ch chan *SendRatingServiceResponse, model *SendRatingServiceModel) {
+func (r *RatingService) SendRating(ctx context.Context, model *SendRatingServiceModel) (*SendRatingServiceResponse, error) {
 	modelErr := r.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		r.loggr.Error(modelErr.Error())
-		ch <- &SendRatingServiceResponse{Error: modelErr}
-		return
+		return nil, service.Wrap(service.ErrValidation, modelErr)
 	}
 
-	chRatingDb := make(chan *rating.AddRatingResponse)
-	defer close(chRatingDb)
+	criteriaErr := r.validateCriteria(model.Criteria)
+	if criteriaErr != nil {
+		r.loggr.Error(criteriaErr.Error())
+		return nil, service.Wrap(service.ErrValidation, criteriaErr)
+	}
 
-	go r.ratingDb.AddRate(chRatingDb, &rating.AddRatingModel{
+	_, dbErr := r.ratingDb.AddRate(ctx, &rating.AddRatingModel{
 		UserName:   model.UserName,
 		ProviderId: model.ProviderId,
 		ServiceId:  model.ServiceId,
 		Rate:       model.Rate,
+		Title:      model.Title,
+		Comment:    model.Comment,
+		Criteria:   model.Criteria,
 	})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
+	}
+	metrics.RatingAdded(model.Rate)
+
+	return &SendRatingServiceResponse{Info: "Added rating for ServiceId: " + model.ServiceId + " getting from ProviderId: " + model.ProviderId}, nil
+}
+
+// SendRatingsBulk
+// Validates every rating on its own and adds the valid ones in a single transaction.
+// Returns a result per rating in request order; only a storage failure fails the whole request.
+// A dry run stops after validation and the lookup of existing ServiceIds and reports the remaining ratings as valid
+// without adding them.
+func (r *RatingService) SendRatingsBulk(ctx context.Context, model *SendRatingsBulkServiceModel) (*SendRatingsBulkServiceResponse, error) {
+	maxItems := r.environment.GetInt(env.RatingBulkMaxItems, 500)
+	if len(model.Ratings) == 0 || len(model.Ratings) > maxItems {
+		err := fmt.Errorf("Ratings must contain between 1 and %d items", maxItems)
+		r.loggr.Error(err.Error())
+		return nil, service.Wrap(service.ErrValidation, err)
+	}
+
+	results := make([]BulkRatingResultModel, len(model.Ratings))
+	pending := make([]int, 0, len(model.Ratings))
+	seen := make(map[string]bool, len(model.Ratings))
+	for i := range model.Ratings {
+		item := &model.Ratings[i]
+		results[i] = BulkRatingResultModel{Index: i, ServiceId: item.ServiceId}
+
+		if err := r.validatr.ValidateStruct(item); err != nil {
+			results[i].Status, results[i].Error = BulkStatusInvalid, err.Error()
+			continue
+		}
+		if err := r.validateCriteria(item.Criteria); err != nil {
+			results[i].Status, results[i].Error = BulkStatusInvalid, err.Error()
+			continue
+		}
+		if seen[item.ServiceId] {
+			results[i].Status, results[i].Error = BulkStatusDuplicate, "Duplicate ServiceId in request: "+item.ServiceId
+			continue
+		}
+
+		seen[item.ServiceId] = true
+		pending = append(pending, i)
+	}
+
+	if model.DryRun && len(pending) > 0 {
+		serviceIds := make([]string, 0, len(pending))
+		for _, i := range pending {
+			serviceIds = append(serviceIds, results[i].ServiceId)
+		}
+
+		dbResponse, dbErr := r.ratingDb.FindRates(ctx, &rating.FindRatesModel{ServiceIds: serviceIds})
+		if dbErr != nil {
+			return nil, storageError(dbErr)
+		}
+
+		for _, i := range pending {
+			if _, ok := dbResponse.Ids[results[i].ServiceId]; ok {
+				results[i].Status, results[i].Error = BulkStatusDuplicate, "Rating already exists for ServiceId: "+results[i].ServiceId
+			} else {
+				results[i].Status = BulkStatusValid
+			}
+		}
+	} else if !model.DryRun && len(pending) > 0 {
+		dbModel := rating.AddRatesModel{Ratings: make([]rating.AddRatingModel, 0, len(pending))}
+		for _, i := range pending {
+			item := model.Ratings[i]
+			dbModel.Ratings = append(dbModel.Ratings, rating.AddRatingModel{
+				UserName:   item.UserName,
+				ProviderId: item.ProviderId,
+				ServiceId:  item.ServiceId,
+				Rate:       item.Rate,
+				Title:      item.Title,
+				Comment:    item.Comment,
+				Criteria:   item.Criteria,
+			})
+		}
+
+		dbResponse, dbErr := r.ratingDb.AddRates(ctx, &dbModel)
+		if dbErr != nil {
+			return nil, storageError(dbErr)
+		}
+
+		for _, i := range pending {
+			if _, ok := dbResponse.Ids[results[i].ServiceId]; ok {
+				results[i].Status = BulkStatusInserted
+				metrics.RatingAdded(model.Ratings[i].Rate)
+			} else {
+				results[i].Status, results[i].Error = BulkStatusDuplicate, "Rating already exists for ServiceId: "+results[i].ServiceId
+			}
+		}
+	}
 
-	dbResponse := <-chRatingDb
-	if dbResponse.Error != nil {
-		ch <- &SendRatingServiceResponse{Error: dbResponse.Error}
-		return
+	response := SendRatingsBulkServiceResponse{Results: results}
+	for _, result := range results {
+		if result.Status == BulkStatusInserted {
+			response.Inserted++
+		}
 	}
 
-	ch <- &SendRatingServiceResponse{Info: "Added rating for ServiceId: " + model.ServiceId + " getting from ProviderId: " + model.ProviderId}
+	return &response, nil
 }
 
-func (r *RatingService) GetAverageRating(ch chan *GetAverageRatingServiceResponse, model *GetAverageRatingServiceModel) {
+func (r *RatingService) UpdateRating(ctx context.Context, model *UpdateRatingServiceModel) (*UpdateRatingServiceResponse, error) {
 	modelErr := r.validatr.ValidateStruct(model)
 	if modelErr != nil {
 		r.loggr.Error(modelErr.Error())
-		ch <- &GetAverageRatingServiceResponse{Error: modelErr}
-		return
+		return nil, service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	_, dbErr := r.ratingDb.UpdateRate(ctx, &rating.UpdateRatingModel{
+		UserName:  model.UserName,
+		ServiceId: model.ServiceId,
+		Rate:      model.Rate,
+		Title:     model.Title,
+		Comment:   model.Comment,
+	})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
 	}
 
-	chRatingDb := make(chan *rating.GetAllRatingsResponse)
-	defer close(chRatingDb)
+	return &UpdateRatingServiceResponse{Info: "Updated rating for ServiceId: " + model.ServiceId}, nil
+}
 
-	go r.ratingDb.GetAllRate(chRatingDb, &rating.GetAllRatingsModel{
-		ProviderId: model.ProviderId,
+func (r *RatingService) DeleteRating(ctx context.Context, model *DeleteRatingServiceModel) (*DeleteRatingServiceResponse, error) {
+	modelErr := r.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		r.loggr.Error(modelErr.Error())
+		return nil, service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	_, dbErr := r.ratingDb.DeleteRate(ctx, &rating.DeleteRatingModel{
+		UserName:  model.UserName,
+		ServiceId: model.ServiceId,
+		AnyOwner:  model.AnyOwner,
 	})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
+	}
+
+	return &DeleteRatingServiceResponse{Info: "Deleted rating for ServiceId: " + model.ServiceId}, nil
+}
+
+// HideRating
+// Hides a rating from averages, distributions and listings, or shows it again.
+func (r *RatingService) HideRating(ctx context.Context, model *HideRatingServiceModel) (*HideRatingServiceResponse, error) {
+	modelErr := r.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		r.loggr.Error(modelErr.Error())
+		return nil, service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	_, dbErr := r.ratingDb.HideRate(ctx, &rating.HideRatingModel{
+		ServiceId: model.ServiceId,
+		Hidden:    model.Hidden,
+	})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
+	}
 
-	dbResponse := <-chRatingDb
-	if dbResponse.Error != nil {
-		ch <- &GetAverageRatingServiceResponse{Error: dbResponse.Error}
-		return
+	if model.Hidden {
+		return &HideRatingServiceResponse{Info: "Hid rating for ServiceId: " + model.ServiceId}, nil
 	}
 
-	if len(dbResponse.Rates) == 0 {
+	return &HideRatingServiceResponse{Info: "Showed rating for ServiceId: " + model.ServiceId}, nil
+}
+
+func (r *RatingService) GetAverageRating(ctx context.Context, model *GetAverageRatingServiceModel) (*GetAverageRatingServiceResponse, error) {
+	modelErr := r.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		r.loggr.Error(modelErr.Error())
+		return nil, service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	since, until := model.Since, model.Until
+	if model.Window != "" {
+		if !since.IsZero() {
+			return nil, service.Wrap(service.ErrValidation, errors.New("window cannot be combined with since"))
+		}
+
+		window, err := parseWindow(model.Window)
+		if err != nil {
+			return nil, service.Wrap(service.ErrValidation, err)
+		}
+
+		end := until
+		if end.IsZero() {
+			end = time.Now()
+		}
+		since = end.Add(-window)
+	}
+
+	mode := model.Mode
+	if mode == "" {
+		mode = AverageModeMean
+	}
+
+	dbResponse, dbErr := r.ratingDb.GetRateAggregate(ctx, &rating.GetRateAggregateModel{
+		ProviderId: model.ProviderId,
+		Since:      since,
+		Until:      until,
+		HalfLife:   r.decayHalfLife(mode),
+	})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
+	}
+
+	if dbResponse.Count == 0 {
 		r.loggr.Error("No ratings found for ProviderId: " + model.ProviderId)
-		ch <- &GetAverageRatingServiceResponse{Error: errors.New("No ratings found for ProviderId: " + model.ProviderId)}
-		return
+		return nil, service.Wrap(service.ErrNotFound, errors.New("No ratings found for ProviderId: "+model.ProviderId))
+	}
+
+	criteriaResponse, dbErr := r.ratingDb.GetCriteriaAverage(ctx, &rating.GetCriteriaAverageModel{
+		ProviderId: model.ProviderId,
+		Since:      since,
+		Until:      until,
+	})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
+	}
+
+	return &GetAverageRatingServiceResponse{AverageRating: AverageRatingModel{
+		ProviderId:       model.ProviderId,
+		AverageRate:      averageRate(mode, dbResponse, r.bayesianPrior(mode)),
+		Mode:             mode,
+		RatingCount:      dbResponse.Count,
+		CriteriaAverages: criteriaResponse.Averages,
+	}}, nil
+}
+
+// GetAverageRatingBatch
+// Returns the average rating of every requested provider in request order, duplicates removed.
+// Providers without ratings get an entry with zero RatingCount and AverageRate instead of an error.
+func (r *RatingService) GetAverageRatingBatch(ctx context.Context, model *GetAverageRatingBatchServiceModel) (*GetAverageRatingBatchServiceResponse, error) {
+	modelErr := r.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		r.loggr.Error(modelErr.Error())
+		return nil, service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	mode := model.Mode
+	if mode == "" {
+		mode = AverageModeMean
+	}
+
+	providerIds := make([]string, 0, len(model.ProviderIds))
+	seen := make(map[string]bool, len(model.ProviderIds))
+	for _, providerId := range model.ProviderIds {
+		if !seen[providerId] {
+			seen[providerId] = true
+			providerIds = append(providerIds, providerId)
+		}
+	}
+
+	dbResponse, dbErr := r.ratingDb.GetRateAggregates(ctx, &rating.GetRateAggregatesModel{
+		ProviderIds: providerIds,
+		HalfLife:    r.decayHalfLife(mode),
+	})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
+	}
+
+	prior := r.bayesianPrior(mode)
+	response := GetAverageRatingBatchServiceResponse{AverageRatings: make([]AverageRatingModel, 0, len(providerIds))}
+	for _, providerId := range providerIds {
+		averageRating := AverageRatingModel{ProviderId: providerId, Mode: mode}
+		if aggregate, ok := dbResponse.Aggregates[providerId]; ok && aggregate.Count > 0 {
+			averageRating.AverageRate = averageRate(mode, &aggregate, prior)
+			averageRating.RatingCount = aggregate.Count
+		}
+		response.AverageRatings = append(response.AverageRatings, averageRating)
+	}
+
+	return &response, nil
+}
+
+// GetTopRatedProviders
+// Returns providers ranked by their average, or by their bayesian average in bayesian mode.
+func (r *RatingService) GetTopRatedProviders(ctx context.Context, model *GetTopRatedProvidersServiceModel) (*GetTopRatedProvidersServiceResponse, error) {
+	modelErr := r.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		r.loggr.Error(modelErr.Error())
+		return nil, service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	limit := model.Limit
+	if limit == 0 {
+		limit = defaultTopLimit
+	}
+
+	mode := model.Mode
+	if mode == "" {
+		mode = AverageModeMean
+	}
+
+	prior := r.bayesianPrior(mode)
+
+	dbResponse, dbErr := r.ratingDb.GetTopProviders(ctx, &rating.GetTopProvidersModel{
+		Limit:       limit,
+		MinCount:    model.MinCount,
+		PriorMean:   prior.mean,
+		PriorWeight: prior.weight,
+	})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
+	}
+
+	response := GetTopRatedProvidersServiceResponse{Providers: make([]AverageRatingModel, 0, len(dbResponse.Providers))}
+	for _, provider := range dbResponse.Providers {
+		response.Providers = append(response.Providers, AverageRatingModel{
+			ProviderId:  provider.ProviderId,
+			AverageRate: provider.Score,
+			Mode:        mode,
+			RatingCount: provider.Count,
+		})
+	}
+
+	return &response, nil
+}
+
+// GetRatingDistribution
+// Returns count and percentage of ratings for every rate from 1 to 5 stars.
+// Providers without ratings get empty buckets rather than an error.
+func (r *RatingService) GetRatingDistribution(ctx context.Context, model *GetRatingDistributionServiceModel) (*GetRatingDistributionServiceResponse, error) {
+	modelErr := r.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		r.loggr.Error(modelErr.Error())
+		return nil, service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	dbResponse, dbErr := r.ratingDb.GetRateDistribution(ctx, &rating.GetRateDistributionModel{
+		ProviderId: model.ProviderId,
+	})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
+	}
+
+	distribution := RatingDistributionModel{
+		ProviderId: model.ProviderId,
+		Buckets:    make([]RatingBucketModel, 0, maxRate-minRate+1),
+	}
+	for rate := minRate; rate <= maxRate; rate++ {
+		distribution.TotalCount += dbResponse.Counts[rate]
+	}
+	for rate := minRate; rate <= maxRate; rate++ {
+		bucket := RatingBucketModel{Rate: rate, Count: dbResponse.Counts[rate]}
+		if distribution.TotalCount > 0 {
+			bucket.Percentage = float64(bucket.Count) * 100 / float64(distribution.TotalCount)
+		}
+		distribution.Buckets = append(distribution.Buckets, bucket)
+	}
+
+	return &GetRatingDistributionServiceResponse{Distribution: distribution}, nil
+}
+
+// ListRatings
+// Returns a page of a provider's ratings, newest first.
+// NextCursor is empty on the last page, otherwise it is passed back as Cursor to get the next page.
+func (r *RatingService) ListRatings(ctx context.Context, model *ListRatingsServiceModel) (*ListRatingsServiceResponse, error) {
+	modelErr := r.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		r.loggr.Error(modelErr.Error())
+		return nil, service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	if model.MinRate != 0 && model.MaxRate != 0 && model.MinRate > model.MaxRate {
+		return nil, service.Wrap(service.ErrValidation, fmt.Errorf("minRate %d is greater than maxRate %d", model.MinRate, model.MaxRate))
+	}
+
+	var beforeId int64
+	if model.Cursor != "" {
+		id, err := strconv.ParseInt(model.Cursor, 10, 64)
+		if err != nil || id < 1 {
+			return nil, service.Wrap(service.ErrValidation, fmt.Errorf("invalid cursor: %s", model.Cursor))
+		}
+		beforeId = id
+	}
+
+	limit := model.Limit
+	if limit == 0 {
+		limit = defaultListLimit
+	}
+
+	// fetch one extra row to know whether there is a next page
+	dbResponse, dbErr := r.ratingDb.ListRates(ctx, &rating.ListRatesModel{
+		ProviderId: model.ProviderId,
+		BeforeId:   beforeId,
+		Limit:      limit + 1,
+		MinRate:    model.MinRate,
+		MaxRate:    model.MaxRate,
+		Since:      model.Since,
+		Until:      model.Until,
+	})
+	if dbErr != nil {
+		return nil, storageError(dbErr)
 	}
 
-	// calculate average rate
-	sum := 0
-	for i := 0; i < len(dbResponse.Rates); i++ {
-		sum += (dbResponse.Rates[i])
+	rates := dbResponse.Rates
+	response := ListRatingsServiceResponse{Ratings: make([]RatingModel, 0, limit)}
+	if len(rates) > limit {
+		rates = rates[:limit]
+		response.NextCursor = strconv.FormatInt(rates[limit-1].Id, 10)
 	}
 
-	avg := (float64(sum)) / (float64(len(dbResponse.Rates)))
+	for _, rate := range rates {
+		response.Ratings = append(response.Ratings, RatingModel{
+			UserName:    rate.UserName,
+			ServiceId:   rate.ServiceId,
+			Rate:        rate.Rate,
+			Title:       rate.Title,
+			Comment:     rate.Comment,
+			CreatedDate: rate.CreatedDate,
+		})
+	}
+
+	return &response, nil
+}
+
+// validateCriteria
+// Returns an error for the first criterion that is not configured in RATING_CRITERIA.
+func (r *RatingService) validateCriteria(criteria map[string]int) error {
+	for criterion := range criteria {
+		if !r.isRatingCriterion(criterion) {
+			return errors.New("Unknown rating criterion: " + criterion)
+		}
+	}
+
+	return nil
+}
+
+// isRatingCriterion
+// Reports whether the criterion is one of the comma separated names configured in RATING_CRITERIA.
+func (r *RatingService) isRatingCriterion(criterion string) bool {
+	for _, name := range strings.Split(r.environment.Get(env.RatingCriteria), ",") {
+		if strings.TrimSpace(name) == criterion {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bayesianPrior
+// Returns the prior configured for bayesian averages, or a zero prior for any other mode.
+func (r *RatingService) bayesianPrior(mode string) bayesianPrior {
+	if mode != AverageModeBayesian {
+		return bayesianPrior{}
+	}
+
+	return bayesianPrior{
+		mean:   r.environment.GetFloat(env.RatingBayesianPriorMean, 3.5),
+		weight: r.environment.GetFloat(env.RatingBayesianPriorWeight, 10),
+	}
+}
+
+// parseWindow
+// Parses a look-back window such as "30d", "2w" or any time.ParseDuration value like "12h".
+func parseWindow(window string) (time.Duration, error) {
+	invalid := fmt.Errorf("invalid window: %s", window)
+
+	var duration time.Duration
+	switch unit := window[len(window)-1]; unit {
+	case 'd', 'w':
+		count, err := strconv.Atoi(window[:len(window)-1])
+		if err != nil {
+			return 0, invalid
+		}
+		duration = time.Duration(count) * 24 * time.Hour
+		if unit == 'w' {
+			duration *= 7
+		}
+	default:
+		parsed, err := time.ParseDuration(window)
+		if err != nil {
+			return 0, invalid
+		}
+		duration = parsed
+	}
+
+	if duration <= 0 {
+		return 0, invalid
+	}
+
+	return duration, nil
+}
+
+// ExportRatings
+// Streams matching ratings oldest first to w as CSV or newline delimited JSON.
+// Nothing is written to w when the model is invalid or the query cannot be started.
+func (r *RatingService) ExportRatings(ctx context.Context, model *ExportRatingsServiceModel, w io.Writer) error {
+	modelErr := r.validatr.ValidateStruct(model)
+	if modelErr != nil {
+		r.loggr.Error(modelErr.Error())
+		return service.Wrap(service.ErrValidation, modelErr)
+	}
+
+	cursor, dbErr := r.ratingDb.ExportRates(ctx, &rating.ExportRatesModel{
+		ProviderId: model.ProviderId,
+		Since:      model.Since,
+	})
+	if dbErr != nil {
+		return storageError(dbErr)
+	}
+	defer cursor.Close()
+
+	encoder := newRatingEncoder(model.Format, w)
+	for cursor.Next() {
+		record, err := cursor.Record()
+		if err != nil {
+			r.loggr.Error(err.Error())
+			return storageError(err)
+		}
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		r.loggr.Error(err.Error())
+		return storageError(err)
+	}
+
+	return encoder.Flush()
+}
+
+// storageError
+// Marks an error returned by the database with the service error kind it stands for.
+func storageError(err error) error {
+	switch {
+	case errors.Is(err, rating.ErrRateNotFound):
+		return service.Wrap(service.ErrNotFound, err)
+	case errors.Is(err, rating.ErrRateNotOwned):
+		return service.Wrap(service.ErrForbidden, err)
+	case errors.Is(err, rating.ErrRateDuplicate):
+		return service.Wrap(service.ErrDuplicate, err)
+	case database.IsUnavailable(err):
+		return service.Wrap(service.ErrUnavailable, err)
+	}
+
+	return err
+}
+
+// decayHalfLife
+// Returns the configured half-life in decay mode, or zero for any other mode.
+func (r *RatingService) decayHalfLife(mode string) time.Duration {
+	if mode != AverageModeDecay {
+		return 0
+	}
 
-	ch <- &GetAverageRatingServiceResponse{AverageRating: AverageRatingModel{ProviderId: model.ProviderId, AverageRate: avg}}
+	return r.environment.GetDuration(env.RatingDecayHalfLife, 90*24*time.Hour)
 }
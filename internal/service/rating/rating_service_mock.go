@@ -5,6 +5,8 @@
 package rating
 
 import (
+	context "context"
+	io "io"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -33,26 +35,166 @@ func (m *MockIRatingService) EXPECT() *MockIRatingServiceMockRecorder {
 	return m.recorder
 }
 
+// DeleteRating mocks base method.
+func (m *MockIRatingService) DeleteRating(ctx context.Context, model *DeleteRatingServiceModel) (*DeleteRatingServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRating", ctx, model)
+	ret0, _ := ret[0].(*DeleteRatingServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRating indicates an expected call of DeleteRating.
+func (mr *MockIRatingServiceMockRecorder) DeleteRating(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRating", reflect.TypeOf((*MockIRatingService)(nil).DeleteRating), ctx, model)
+}
+
+// ExportRatings mocks base method.
+func (m *MockIRatingService) ExportRatings(ctx context.Context, model *ExportRatingsServiceModel, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportRatings", ctx, model, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportRatings indicates an expected call of ExportRatings.
+func (mr *MockIRatingServiceMockRecorder) ExportRatings(ctx, model, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportRatings", reflect.TypeOf((*MockIRatingService)(nil).ExportRatings), ctx, model, w)
+}
+
 // GetAverageRating mocks base method.
-func (m *MockIRatingService) GetAverageRating(ch chan *GetAverageRatingServiceResponse, model *GetAverageRatingServiceModel) {
+func (m *MockIRatingService) GetAverageRating(ctx context.Context, model *GetAverageRatingServiceModel) (*GetAverageRatingServiceResponse, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GetAverageRating", ch, model)
+	ret := m.ctrl.Call(m, "GetAverageRating", ctx, model)
+	ret0, _ := ret[0].(*GetAverageRatingServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetAverageRating indicates an expected call of GetAverageRating.
-func (mr *MockIRatingServiceMockRecorder) GetAverageRating(ch, model interface{}) *gomock.Call {
+func (mr *MockIRatingServiceMockRecorder) GetAverageRating(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageRating", reflect.TypeOf((*MockIRatingService)(nil).GetAverageRating), ctx, model)
+}
+
+// GetAverageRatingBatch mocks base method.
+func (m *MockIRatingService) GetAverageRatingBatch(ctx context.Context, model *GetAverageRatingBatchServiceModel) (*GetAverageRatingBatchServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAverageRatingBatch", ctx, model)
+	ret0, _ := ret[0].(*GetAverageRatingBatchServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAverageRatingBatch indicates an expected call of GetAverageRatingBatch.
+func (mr *MockIRatingServiceMockRecorder) GetAverageRatingBatch(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageRatingBatch", reflect.TypeOf((*MockIRatingService)(nil).GetAverageRatingBatch), ctx, model)
+}
+
+// GetRatingDistribution mocks base method.
+func (m *MockIRatingService) GetRatingDistribution(ctx context.Context, model *GetRatingDistributionServiceModel) (*GetRatingDistributionServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingDistribution", ctx, model)
+	ret0, _ := ret[0].(*GetRatingDistributionServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatingDistribution indicates an expected call of GetRatingDistribution.
+func (mr *MockIRatingServiceMockRecorder) GetRatingDistribution(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingDistribution", reflect.TypeOf((*MockIRatingService)(nil).GetRatingDistribution), ctx, model)
+}
+
+// GetTopRatedProviders mocks base method.
+func (m *MockIRatingService) GetTopRatedProviders(ctx context.Context, model *GetTopRatedProvidersServiceModel) (*GetTopRatedProvidersServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopRatedProviders", ctx, model)
+	ret0, _ := ret[0].(*GetTopRatedProvidersServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopRatedProviders indicates an expected call of GetTopRatedProviders.
+func (mr *MockIRatingServiceMockRecorder) GetTopRatedProviders(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopRatedProviders", reflect.TypeOf((*MockIRatingService)(nil).GetTopRatedProviders), ctx, model)
+}
+
+// HideRating mocks base method.
+func (m *MockIRatingService) HideRating(ctx context.Context, model *HideRatingServiceModel) (*HideRatingServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HideRating", ctx, model)
+	ret0, _ := ret[0].(*HideRatingServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HideRating indicates an expected call of HideRating.
+func (mr *MockIRatingServiceMockRecorder) HideRating(ctx, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageRating", reflect.TypeOf((*MockIRatingService)(nil).GetAverageRating), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HideRating", reflect.TypeOf((*MockIRatingService)(nil).HideRating), ctx, model)
+}
+
+// ListRatings mocks base method.
+func (m *MockIRatingService) ListRatings(ctx context.Context, model *ListRatingsServiceModel) (*ListRatingsServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRatings", ctx, model)
+	ret0, _ := ret[0].(*ListRatingsServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRatings indicates an expected call of ListRatings.
+func (mr *MockIRatingServiceMockRecorder) ListRatings(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRatings", reflect.TypeOf((*MockIRatingService)(nil).ListRatings), ctx, model)
 }
 
 // SendRating mocks base method.
-func (m *MockIRatingService) SendRating(ch chan *SendRatingServiceResponse, model *SendRatingServiceModel) {
+func (m *MockIRatingService) SendRating(ctx context.Context, model *SendRatingServiceModel) (*SendRatingServiceResponse, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SendRating", ch, model)
+	ret := m.ctrl.Call(m, "SendRating", ctx, model)
+	ret0, _ := ret[0].(*SendRatingServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SendRating indicates an expected call of SendRating.
-func (mr *MockIRatingServiceMockRecorder) SendRating(ch, model interface{}) *gomock.Call {
+func (mr *MockIRatingServiceMockRecorder) SendRating(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRating", reflect.TypeOf((*MockIRatingService)(nil).SendRating), ctx, model)
+}
+
+// SendRatingsBulk mocks base method.
+func (m *MockIRatingService) SendRatingsBulk(ctx context.Context, model *SendRatingsBulkServiceModel) (*SendRatingsBulkServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRatingsBulk", ctx, model)
+	ret0, _ := ret[0].(*SendRatingsBulkServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendRatingsBulk indicates an expected call of SendRatingsBulk.
+func (mr *MockIRatingServiceMockRecorder) SendRatingsBulk(ctx, model interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRatingsBulk", reflect.TypeOf((*MockIRatingService)(nil).SendRatingsBulk), ctx, model)
+}
+
+// UpdateRating mocks base method.
+func (m *MockIRatingService) UpdateRating(ctx context.Context, model *UpdateRatingServiceModel) (*UpdateRatingServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRating", ctx, model)
+	ret0, _ := ret[0].(*UpdateRatingServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRating indicates an expected call of UpdateRating.
+func (mr *MockIRatingServiceMockRecorder) UpdateRating(ctx, model interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRating", reflect.TypeOf((*MockIRatingService)(nil).SendRating), ch, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRating", reflect.TypeOf((*MockIRatingService)(nil).UpdateRating), ctx, model)
 }
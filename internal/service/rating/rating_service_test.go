@@ -1,12 +1,19 @@
 package rating
 
 import (
+	"bytes"
+	"context"
+	"database/sql/driver"
 	"errors"
+	"fmt"
 	ratingDb "rating-api/internal/data/database/rating"
+	"rating-api/internal/service"
 	"rating-api/internal/util/env"
 	"rating-api/internal/util/logger"
 	"rating-api/internal/util/validator"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
@@ -52,6 +59,8 @@ func (r *RatingServiceTestSuite) TestSendRating_HappyPath_Success() {
 		ProviderId: "test-1",
 		ServiceId:  "s-1",
 		Rate:       4,
+		Title:      "On time",
+		Comment:    "Arrived early and did a careful job.",
 	}
 
 	r.mockValidator.
@@ -61,22 +70,84 @@ func (r *RatingServiceTestSuite) TestSendRating_HappyPath_Success() {
 
 	r.mockRatingDb.
 		EXPECT().
-		AddRate(gomock.Any(), gomock.Any()).
-		DoAndReturn(
-			func(ch chan *ratingDb.AddRatingResponse, model *ratingDb.AddRatingModel) {
-				ch <- &ratingDb.AddRatingResponse{
-					Error: nil,
-				}
-			},
-		)
+		AddRate(gomock.Any(), gomock.Eq(&ratingDb.AddRatingModel{
+			UserName:   "emre.bilal",
+			ProviderId: "test-1",
+			ServiceId:  "s-1",
+			Rate:       4,
+			Title:      "On time",
+			Comment:    "Arrived early and did a careful job.",
+		})).
+		Return(&ratingDb.AddRatingResponse{Id: 1}, nil)
+
+	response, err := r.ratingService.SendRating(context.Background(), &model)
+
+	r.Nil(err)
+	r.NotNil(response)
+}
+
+func (r *RatingServiceTestSuite) TestSendRating_WithCriteria_Success() {
+	model := SendRatingServiceModel{
+		UserName:   "emre.bilal",
+		ProviderId: "test-1",
+		ServiceId:  "s-1",
+		Rate:       4,
+		Criteria:   map[string]int{"punctuality": 5, "quality": 3},
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockEnvironment.
+		EXPECT().
+		Get(env.RatingCriteria).
+		Return("punctuality, quality,communication").
+		AnyTimes()
+
+	r.mockRatingDb.
+		EXPECT().
+		AddRate(gomock.Any(), gomock.Eq(&ratingDb.AddRatingModel{
+			UserName:   "emre.bilal",
+			ProviderId: "test-1",
+			ServiceId:  "s-1",
+			Rate:       4,
+			Criteria:   map[string]int{"punctuality": 5, "quality": 3},
+		})).
+		Return(&ratingDb.AddRatingResponse{Id: 1}, nil)
+
+	response, err := r.ratingService.SendRating(context.Background(), &model)
+
+	r.Nil(err)
+	r.NotNil(response)
+}
+
+func (r *RatingServiceTestSuite) TestSendRating_UnknownCriterion_ReturnsError() {
+	model := SendRatingServiceModel{
+		UserName:   "emre.bilal",
+		ProviderId: "test-1",
+		ServiceId:  "s-1",
+		Rate:       4,
+		Criteria:   map[string]int{"friendliness": 5},
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
 
-	ch := make(chan *SendRatingServiceResponse)
-	defer close(ch)
+	r.mockEnvironment.
+		EXPECT().
+		Get(env.RatingCriteria).
+		Return("punctuality,quality,communication")
+
+	r.mockLogger.EXPECT().Error(gomock.Any())
 
-	go r.ratingService.SendRating(ch, &model)
-	response := <-ch
+	response, err := r.ratingService.SendRating(context.Background(), &model)
 
-	r.Nil(response.Error)
+	r.Nil(response)
+	r.EqualError(err, "Unknown rating criterion: friendliness")
 }
 
 func (r *RatingServiceTestSuite) TestSendRating_ModelValidationError_ReturnsError() {
@@ -94,14 +165,11 @@ func (r *RatingServiceTestSuite) TestSendRating_ModelValidationError_ReturnsErro
 
 	r.mockLogger.EXPECT().Error(gomock.Any())
 
-	ch := make(chan *SendRatingServiceResponse)
-	defer close(ch)
-
-	go r.ratingService.SendRating(ch, &model)
-	response := <-ch
+	response, err := r.ratingService.SendRating(context.Background(), &model)
 
-	r.NotNil(response.Error)
-	r.EqualError(response.Error, "Rate must be between 1 and 5")
+	r.Nil(response)
+	r.EqualError(err, "Rate must be between 1 and 5")
+	r.ErrorIs(err, service.ErrValidation)
 }
 
 func (r *RatingServiceTestSuite) TestSendRating_DatabaseError_ReturnsError() {
@@ -120,22 +188,62 @@ func (r *RatingServiceTestSuite) TestSendRating_DatabaseError_ReturnsError() {
 	r.mockRatingDb.
 		EXPECT().
 		AddRate(gomock.Any(), gomock.Any()).
-		DoAndReturn(
-			func(ch chan *ratingDb.AddRatingResponse, model *ratingDb.AddRatingModel) {
-				ch <- &ratingDb.AddRatingResponse{
-					Error: errors.New("an error occurred"),
-				}
-			},
-		)
+		Return(nil, errors.New("an error occurred"))
+
+	response, err := r.ratingService.SendRating(context.Background(), &model)
+
+	r.Nil(response)
+	r.Error(err)
+}
+
+func (r *RatingServiceTestSuite) TestSendRating_Duplicate_ReturnsErrDuplicate() {
+	model := SendRatingServiceModel{
+		UserName:   "emre.bilal",
+		ProviderId: "test-1",
+		ServiceId:  "s-1",
+		Rate:       4,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		AddRate(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("%w for ServiceId: s-1", ratingDb.ErrRateDuplicate))
+
+	response, err := r.ratingService.SendRating(context.Background(), &model)
+
+	r.Nil(response)
+	r.EqualError(err, "rate already exists for ServiceId: s-1")
+	r.ErrorIs(err, ErrRatingDuplicate)
+	r.ErrorIs(err, service.ErrDuplicate)
+}
+
+func (r *RatingServiceTestSuite) TestSendRating_DatabaseUnavailable_ReturnsErrUnavailable() {
+	model := SendRatingServiceModel{
+		UserName:   "emre.bilal",
+		ProviderId: "test-1",
+		ServiceId:  "s-1",
+		Rate:       4,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
 
-	ch := make(chan *SendRatingServiceResponse)
-	defer close(ch)
+	r.mockRatingDb.
+		EXPECT().
+		AddRate(gomock.Any(), gomock.Any()).
+		Return(nil, driver.ErrBadConn)
 
-	go r.ratingService.SendRating(ch, &model)
-	response := <-ch
+	response, err := r.ratingService.SendRating(context.Background(), &model)
 
-	r.NotNil(response.Error)
-	r.Error(response.Error)
+	r.Nil(response)
+	r.ErrorIs(err, service.ErrUnavailable)
 }
 
 func (r *RatingServiceTestSuite) TestGetAverageRating_HappyPath_Success() {
@@ -150,26 +258,22 @@ func (r *RatingServiceTestSuite) TestGetAverageRating_HappyPath_Success() {
 
 	r.mockRatingDb.
 		EXPECT().
-		GetAllRate(gomock.Any(), gomock.Any()).
-		DoAndReturn(
-			func(ch chan *ratingDb.GetAllRatingsResponse, model *ratingDb.GetAllRatingsModel) {
-				ch <- &ratingDb.GetAllRatingsResponse{
-					Error: nil,
-					Rates: []int{4, 5, 4, 3},
-				}
-			},
-		)
+		GetRateAggregate(gomock.Any(), gomock.Eq(&ratingDb.GetRateAggregateModel{ProviderId: "test-1"})).
+		Return(&ratingDb.GetRateAggregateResponse{Count: 4, Sum: 16, Average: 4, Min: 3, Max: 5}, nil)
 
-	avgRate := (4 + 5 + 4 + 3) / 4
+	r.mockRatingDb.
+		EXPECT().
+		GetCriteriaAverage(gomock.Any(), gomock.Eq(&ratingDb.GetCriteriaAverageModel{ProviderId: "test-1"})).
+		Return(&ratingDb.GetCriteriaAverageResponse{Averages: map[string]float64{"quality": 4.5}}, nil)
 
-	ch := make(chan *GetAverageRatingServiceResponse)
-	defer close(ch)
+	avgRate := (4 + 5 + 4 + 3) / 4
 
-	go r.ratingService.GetAverageRating(ch, &model)
-	response := <-ch
+	response, err := r.ratingService.GetAverageRating(context.Background(), &model)
 
-	r.Nil(response.Error)
+	r.Nil(err)
+	r.NotNil(response)
 	r.Equal(response.AverageRating.AverageRate, float64(avgRate))
+	r.Equal(map[string]float64{"quality": 4.5}, response.AverageRating.CriteriaAverages)
 }
 
 func (r *RatingServiceTestSuite) TestGetAverageRating_ModelValidationError_ReturnsError() {
@@ -184,14 +288,10 @@ func (r *RatingServiceTestSuite) TestGetAverageRating_ModelValidationError_Retur
 
 	r.mockLogger.EXPECT().Error(gomock.Any())
 
-	ch := make(chan *GetAverageRatingServiceResponse)
-	defer close(ch)
-
-	go r.ratingService.GetAverageRating(ch, &model)
-	response := <-ch
+	response, err := r.ratingService.GetAverageRating(context.Background(), &model)
 
-	r.NotNil(response.Error)
-	r.EqualError(response.Error, "ProviderId cannot be empty")
+	r.Nil(response)
+	r.EqualError(err, "ProviderId cannot be empty")
 }
 
 func (r *RatingServiceTestSuite) TestGetAverageRating_DatabaseError_ReturnsError() {
@@ -206,24 +306,13 @@ func (r *RatingServiceTestSuite) TestGetAverageRating_DatabaseError_ReturnsError
 
 	r.mockRatingDb.
 		EXPECT().
-		GetAllRate(gomock.Any(), gomock.Any()).
-		DoAndReturn(
-			func(ch chan *ratingDb.GetAllRatingsResponse, model *ratingDb.GetAllRatingsModel) {
-				ch <- &ratingDb.GetAllRatingsResponse{
-					Error: errors.New("an error occurred"),
-					Rates: []int{},
-				}
-			},
-		)
+		GetRateAggregate(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("an error occurred"))
 
-	ch := make(chan *GetAverageRatingServiceResponse)
-	defer close(ch)
+	response, err := r.ratingService.GetAverageRating(context.Background(), &model)
 
-	go r.ratingService.GetAverageRating(ch, &model)
-	response := <-ch
-
-	r.NotNil(response.Error)
-	r.Error(response.Error)
+	r.Nil(response)
+	r.Error(err)
 }
 
 func (r *RatingServiceTestSuite) TestGetAverageRating_NoRatingsFound_ReturnsError() {
@@ -238,24 +327,927 @@ func (r *RatingServiceTestSuite) TestGetAverageRating_NoRatingsFound_ReturnsErro
 
 	r.mockRatingDb.
 		EXPECT().
-		GetAllRate(gomock.Any(), gomock.Any()).
-		DoAndReturn(
-			func(ch chan *ratingDb.GetAllRatingsResponse, model *ratingDb.GetAllRatingsModel) {
-				ch <- &ratingDb.GetAllRatingsResponse{
-					Error: nil,
-					Rates: []int{},
-				}
-			},
-		)
+		GetRateAggregate(gomock.Any(), gomock.Any()).
+		Return(&ratingDb.GetRateAggregateResponse{}, nil)
 
 	r.mockLogger.EXPECT().Error(gomock.Any())
 
-	ch := make(chan *GetAverageRatingServiceResponse)
-	defer close(ch)
+	response, err := r.ratingService.GetAverageRating(context.Background(), &model)
+
+	r.Nil(response)
+	r.EqualError(err, "No ratings found for ProviderId: "+model.ProviderId)
+	r.ErrorIs(err, service.ErrNotFound)
+}
+
+func (r *RatingServiceTestSuite) TestGetRatingDistribution_HappyPath_Success() {
+	model := GetRatingDistributionServiceModel{
+		ProviderId: "test-1",
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetRateDistribution(gomock.Any(), gomock.Eq(&ratingDb.GetRateDistributionModel{ProviderId: "test-1"})).
+		Return(&ratingDb.GetRateDistributionResponse{Counts: map[int]int{5: 3, 4: 1}}, nil)
+
+	response, err := r.ratingService.GetRatingDistribution(context.Background(), &model)
+
+	r.Nil(err)
+	r.Equal(4, response.Distribution.TotalCount)
+	r.Len(response.Distribution.Buckets, 5)
+	r.Equal(RatingBucketModel{Rate: 1, Count: 0, Percentage: 0}, response.Distribution.Buckets[0])
+	r.Equal(RatingBucketModel{Rate: 4, Count: 1, Percentage: 25}, response.Distribution.Buckets[3])
+	r.Equal(RatingBucketModel{Rate: 5, Count: 3, Percentage: 75}, response.Distribution.Buckets[4])
+}
+
+func (r *RatingServiceTestSuite) TestGetRatingDistribution_NoRatingsFound_ReturnsEmptyBuckets() {
+	model := GetRatingDistributionServiceModel{
+		ProviderId: "test-1",
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetRateDistribution(gomock.Any(), gomock.Any()).
+		Return(&ratingDb.GetRateDistributionResponse{Counts: map[int]int{}}, nil)
+
+	response, err := r.ratingService.GetRatingDistribution(context.Background(), &model)
+
+	r.Nil(err)
+	r.Equal(0, response.Distribution.TotalCount)
+	r.Len(response.Distribution.Buckets, 5)
+	for _, bucket := range response.Distribution.Buckets {
+		r.Equal(0, bucket.Count)
+		r.Equal(float64(0), bucket.Percentage)
+	}
+}
+
+func (r *RatingServiceTestSuite) TestGetRatingDistribution_DatabaseError_ReturnsError() {
+	model := GetRatingDistributionServiceModel{
+		ProviderId: "test-1",
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetRateDistribution(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("an error occurred"))
+
+	response, err := r.ratingService.GetRatingDistribution(context.Background(), &model)
+
+	r.Nil(response)
+	r.Error(err)
+}
+
+func (r *RatingServiceTestSuite) TestUpdateRating_HappyPath_Success() {
+	model := UpdateRatingServiceModel{
+		UserName:  "emre.bilal",
+		ServiceId: "s-1",
+		Rate:      2,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		UpdateRate(gomock.Any(), gomock.Eq(&ratingDb.UpdateRatingModel{UserName: "emre.bilal", ServiceId: "s-1", Rate: 2})).
+		Return(&ratingDb.UpdateRatingResponse{Id: 1}, nil)
+
+	response, err := r.ratingService.UpdateRating(context.Background(), &model)
+
+	r.Nil(err)
+	r.NotNil(response)
+}
+
+func (r *RatingServiceTestSuite) TestUpdateRating_NotOwner_ReturnsError() {
+	model := UpdateRatingServiceModel{
+		UserName:  "someone.else",
+		ServiceId: "s-1",
+		Rate:      2,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		UpdateRate(gomock.Any(), gomock.Any()).
+		Return(nil, ratingDb.ErrRateNotOwned)
+
+	response, err := r.ratingService.UpdateRating(context.Background(), &model)
+
+	r.Nil(response)
+	r.ErrorIs(err, ErrRatingNotOwned)
+	r.ErrorIs(err, service.ErrForbidden)
+}
+
+func (r *RatingServiceTestSuite) TestDeleteRating_HappyPath_Success() {
+	model := DeleteRatingServiceModel{
+		UserName:  "emre.bilal",
+		ServiceId: "s-1",
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		DeleteRate(gomock.Any(), gomock.Eq(&ratingDb.DeleteRatingModel{UserName: "emre.bilal", ServiceId: "s-1"})).
+		Return(&ratingDb.DeleteRatingResponse{Id: 1}, nil)
+
+	response, err := r.ratingService.DeleteRating(context.Background(), &model)
+
+	r.Nil(err)
+	r.NotNil(response)
+}
+
+func (r *RatingServiceTestSuite) TestDeleteRating_NotFound_ReturnsError() {
+	model := DeleteRatingServiceModel{
+		UserName:  "emre.bilal",
+		ServiceId: "s-404",
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		DeleteRate(gomock.Any(), gomock.Any()).
+		Return(nil, ratingDb.ErrRateNotFound)
+
+	response, err := r.ratingService.DeleteRating(context.Background(), &model)
+
+	r.Nil(response)
+	r.ErrorIs(err, ErrRatingNotFound)
+	r.ErrorIs(err, service.ErrNotFound)
+}
+
+func (r *RatingServiceTestSuite) TestDeleteRating_AnyOwner_PassesAnyOwner() {
+	model := DeleteRatingServiceModel{
+		UserName:  "admin",
+		ServiceId: "s-1",
+		AnyOwner:  true,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		DeleteRate(gomock.Any(), gomock.Eq(&ratingDb.DeleteRatingModel{UserName: "admin", ServiceId: "s-1", AnyOwner: true})).
+		Return(&ratingDb.DeleteRatingResponse{Id: 1}, nil)
+
+	response, err := r.ratingService.DeleteRating(context.Background(), &model)
+
+	r.Nil(err)
+	r.NotNil(response)
+}
+
+func (r *RatingServiceTestSuite) TestHideRating_HappyPath_Success() {
+	model := HideRatingServiceModel{
+		ServiceId: "s-1",
+		Hidden:    true,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		HideRate(gomock.Any(), gomock.Eq(&ratingDb.HideRatingModel{ServiceId: "s-1", Hidden: true})).
+		Return(&ratingDb.HideRatingResponse{Id: 1}, nil)
+
+	response, err := r.ratingService.HideRating(context.Background(), &model)
+
+	r.Nil(err)
+	r.Equal("Hid rating for ServiceId: s-1", response.Info)
+}
+
+func (r *RatingServiceTestSuite) TestHideRating_NotFound_ReturnsError() {
+	model := HideRatingServiceModel{
+		ServiceId: "s-404",
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		HideRate(gomock.Any(), gomock.Any()).
+		Return(nil, ratingDb.ErrRateNotFound)
+
+	response, err := r.ratingService.HideRating(context.Background(), &model)
+
+	r.Nil(response)
+	r.ErrorIs(err, ErrRatingNotFound)
+	r.ErrorIs(err, service.ErrNotFound)
+}
+
+func (r *RatingServiceTestSuite) TestListRatings_MoreRowsThanLimit_ReturnsNextCursor() {
+	model := ListRatingsServiceModel{
+		ProviderId: "test-1",
+		Cursor:     "100",
+		Limit:      2,
+		MinRate:    3,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		ListRates(gomock.Any(), gomock.Eq(&ratingDb.ListRatesModel{ProviderId: "test-1", BeforeId: 100, Limit: 3, MinRate: 3})).
+		Return(&ratingDb.ListRatesResponse{Rates: []ratingDb.RateRecord{
+			{Id: 99, UserName: "u-1", ServiceId: "s-99", Rate: 5},
+			{Id: 97, UserName: "u-2", ServiceId: "s-97", Rate: 4},
+			{Id: 90, UserName: "u-3", ServiceId: "s-90", Rate: 3},
+		}}, nil)
+
+	response, err := r.ratingService.ListRatings(context.Background(), &model)
+
+	r.Nil(err)
+	r.Len(response.Ratings, 2)
+	r.Equal("s-99", response.Ratings[0].ServiceId)
+	r.Equal("s-97", response.Ratings[1].ServiceId)
+	r.Equal("97", response.NextCursor)
+}
+
+func (r *RatingServiceTestSuite) TestListRatings_LastPage_ReturnsEmptyCursor() {
+	model := ListRatingsServiceModel{
+		ProviderId: "test-1",
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		ListRates(gomock.Any(), gomock.Eq(&ratingDb.ListRatesModel{ProviderId: "test-1", Limit: defaultListLimit + 1})).
+		Return(&ratingDb.ListRatesResponse{Rates: []ratingDb.RateRecord{
+			{Id: 1, UserName: "u-1", ServiceId: "s-1", Rate: 5},
+		}}, nil)
+
+	response, err := r.ratingService.ListRatings(context.Background(), &model)
+
+	r.Nil(err)
+	r.Len(response.Ratings, 1)
+	r.Empty(response.NextCursor)
+}
+
+func (r *RatingServiceTestSuite) TestListRatings_InvalidCursor_ReturnsError() {
+	model := ListRatingsServiceModel{
+		ProviderId: "test-1",
+		Cursor:     "abc",
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	response, err := r.ratingService.ListRatings(context.Background(), &model)
+
+	r.Nil(response)
+	r.EqualError(err, "invalid cursor: abc")
+}
+
+func (r *RatingServiceTestSuite) TestListRatings_MinRateAboveMaxRate_ReturnsValidationError() {
+	model := ListRatingsServiceModel{
+		ProviderId: "test-1",
+		MinRate:    4,
+		MaxRate:    2,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	response, err := r.ratingService.ListRatings(context.Background(), &model)
+
+	r.Nil(response)
+	r.ErrorIs(err, service.ErrValidation)
+	r.EqualError(err, "minRate 4 is greater than maxRate 2")
+}
+
+func (r *RatingServiceTestSuite) TestListRatings_OnlyMinRate_IsNotComparedWithMaxRate() {
+	model := ListRatingsServiceModel{
+		ProviderId: "test-1",
+		MinRate:    4,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		ListRates(gomock.Any(), gomock.Eq(&ratingDb.ListRatesModel{ProviderId: "test-1", Limit: defaultListLimit + 1, MinRate: 4})).
+		Return(&ratingDb.ListRatesResponse{}, nil)
+
+	response, err := r.ratingService.ListRatings(context.Background(), &model)
+
+	r.Nil(err)
+	r.Empty(response.Ratings)
+}
+
+func (r *RatingServiceTestSuite) TestGetAverageRating_BayesianMode_PullsTowardsPrior() {
+	model := GetAverageRatingServiceModel{
+		ProviderId: "test-1",
+		Mode:       AverageModeBayesian,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetRateAggregate(gomock.Any(), gomock.Any()).
+		Return(&ratingDb.GetRateAggregateResponse{Count: 1, Positive: 1, Sum: 5, Average: 5, Min: 5, Max: 5}, nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetCriteriaAverage(gomock.Any(), gomock.Any()).
+		Return(&ratingDb.GetCriteriaAverageResponse{Averages: map[string]float64{}}, nil)
+
+	r.mockEnvironment.EXPECT().GetFloat(env.RatingBayesianPriorMean, gomock.Any()).Return(3.5)
+	r.mockEnvironment.EXPECT().GetFloat(env.RatingBayesianPriorWeight, gomock.Any()).Return(float64(9))
+
+	response, err := r.ratingService.GetAverageRating(context.Background(), &model)
+
+	r.Nil(err)
+	r.Equal(AverageModeBayesian, response.AverageRating.Mode)
+	r.Equal(1, response.AverageRating.RatingCount)
+	r.InDelta((9*3.5+5)/10, response.AverageRating.AverageRate, 0.0001)
+}
+
+func (r *RatingServiceTestSuite) TestGetAverageRating_WilsonMode_ReturnsLowerBound() {
+	model := GetAverageRatingServiceModel{
+		ProviderId: "test-1",
+		Mode:       AverageModeWilson,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetRateAggregate(gomock.Any(), gomock.Any()).
+		Return(&ratingDb.GetRateAggregateResponse{Count: 10, Positive: 9, Sum: 45, Average: 4.5, Min: 3, Max: 5}, nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetCriteriaAverage(gomock.Any(), gomock.Any()).
+		Return(&ratingDb.GetCriteriaAverageResponse{Averages: map[string]float64{}}, nil)
+
+	response, err := r.ratingService.GetAverageRating(context.Background(), &model)
+
+	r.Nil(err)
+	r.Equal(AverageModeWilson, response.AverageRating.Mode)
+	r.Equal(10, response.AverageRating.RatingCount)
+	// lower bound 0.5958 of 9 positive out of 10, mapped onto 1 to 5 stars
+	r.InDelta(3.3834, response.AverageRating.AverageRate, 0.0001)
+}
+
+func (r *RatingServiceTestSuite) TestBayesianAverage_FewRatings_RankBelowManyRatings() {
+	prior := bayesianPrior{mean: 3.5, weight: 10}
+
+	single := bayesianAverage(5, 1, prior)
+	many := bayesianAverage(1920, 400, prior)
+
+	r.Less(single, many)
+}
+
+func (r *RatingServiceTestSuite) TestGetAverageRating_Window_RestrictsSinceBeforeUntil() {
+	until := time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)
+	model := GetAverageRatingServiceModel{
+		ProviderId: "test-1",
+		Until:      until,
+		Window:     "30d",
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	since := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	r.mockRatingDb.
+		EXPECT().
+		GetRateAggregate(gomock.Any(), gomock.Eq(&ratingDb.GetRateAggregateModel{ProviderId: "test-1", Since: since, Until: until})).
+		Return(&ratingDb.GetRateAggregateResponse{Count: 2, Sum: 9, Average: 4.5, Min: 4, Max: 5}, nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetCriteriaAverage(gomock.Any(), gomock.Eq(&ratingDb.GetCriteriaAverageModel{ProviderId: "test-1", Since: since, Until: until})).
+		Return(&ratingDb.GetCriteriaAverageResponse{Averages: map[string]float64{}}, nil)
+
+	response, err := r.ratingService.GetAverageRating(context.Background(), &model)
+
+	r.Nil(err)
+	r.Equal(4.5, response.AverageRating.AverageRate)
+	r.Equal(2, response.AverageRating.RatingCount)
+}
+
+func (r *RatingServiceTestSuite) TestGetAverageRating_DecayMode_ReturnsDecayedAverage() {
+	model := GetAverageRatingServiceModel{
+		ProviderId: "test-1",
+		Mode:       AverageModeDecay,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockEnvironment.
+		EXPECT().
+		GetDuration(env.RatingDecayHalfLife, gomock.Any()).
+		Return(30 * 24 * time.Hour)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetRateAggregate(gomock.Any(), gomock.Eq(&ratingDb.GetRateAggregateModel{ProviderId: "test-1", HalfLife: 30 * 24 * time.Hour})).
+		Return(&ratingDb.GetRateAggregateResponse{Count: 2, Sum: 6, Average: 3, Min: 1, Max: 5, DecayedAverage: 4.2}, nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetCriteriaAverage(gomock.Any(), gomock.Any()).
+		Return(&ratingDb.GetCriteriaAverageResponse{Averages: map[string]float64{}}, nil)
+
+	response, err := r.ratingService.GetAverageRating(context.Background(), &model)
+
+	r.Nil(err)
+	r.Equal(AverageModeDecay, response.AverageRating.Mode)
+	r.Equal(4.2, response.AverageRating.AverageRate)
+}
+
+func (r *RatingServiceTestSuite) TestGetAverageRating_InvalidWindow_ReturnsError() {
+	model := GetAverageRatingServiceModel{
+		ProviderId: "test-1",
+		Window:     "thirty days",
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	response, err := r.ratingService.GetAverageRating(context.Background(), &model)
+
+	r.Nil(response)
+	r.EqualError(err, "invalid window: thirty days")
+}
+
+func (r *RatingServiceTestSuite) TestGetAverageRating_WindowWithSince_ReturnsError() {
+	model := GetAverageRatingServiceModel{
+		ProviderId: "test-1",
+		Since:      time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		Window:     "30d",
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	response, err := r.ratingService.GetAverageRating(context.Background(), &model)
+
+	r.Nil(response)
+	r.EqualError(err, "window cannot be combined with since")
+}
+
+func (r *RatingServiceTestSuite) TestGetTopRatedProviders_BayesianMode_PassesPrior() {
+	model := GetTopRatedProvidersServiceModel{
+		MinCount: 5,
+		Mode:     AverageModeBayesian,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockEnvironment.EXPECT().GetFloat(env.RatingBayesianPriorMean, gomock.Any()).Return(3.5)
+	r.mockEnvironment.EXPECT().GetFloat(env.RatingBayesianPriorWeight, gomock.Any()).Return(float64(10))
+
+	r.mockRatingDb.
+		EXPECT().
+		GetTopProviders(gomock.Any(), gomock.Eq(&ratingDb.GetTopProvidersModel{Limit: defaultTopLimit, MinCount: 5, PriorMean: 3.5, PriorWeight: 10})).
+		Return(&ratingDb.GetTopProvidersResponse{Providers: []ratingDb.ProviderScore{
+			{ProviderId: "p-1", Count: 400, Average: 4.8, Score: 4.77},
+			{ProviderId: "p-2", Count: 5, Average: 5, Score: 3.83},
+		}}, nil)
+
+	response, err := r.ratingService.GetTopRatedProviders(context.Background(), &model)
+
+	r.Nil(err)
+	r.Equal([]AverageRatingModel{
+		{ProviderId: "p-1", AverageRate: 4.77, Mode: AverageModeBayesian, RatingCount: 400},
+		{ProviderId: "p-2", AverageRate: 3.83, Mode: AverageModeBayesian, RatingCount: 5},
+	}, response.Providers)
+}
+
+func (r *RatingServiceTestSuite) TestGetTopRatedProviders_MeanMode_UsesNoPrior() {
+	model := GetTopRatedProvidersServiceModel{
+		Limit: 3,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetTopProviders(gomock.Any(), gomock.Eq(&ratingDb.GetTopProvidersModel{Limit: 3})).
+		Return(&ratingDb.GetTopProvidersResponse{Providers: []ratingDb.ProviderScore{}}, nil)
+
+	response, err := r.ratingService.GetTopRatedProviders(context.Background(), &model)
+
+	r.Nil(err)
+	r.Empty(response.Providers)
+}
+
+func (r *RatingServiceTestSuite) TestGetAverageRatingBatch_MissingProvider_ReturnsNoRatingsEntry() {
+	model := GetAverageRatingBatchServiceModel{
+		ProviderIds: []string{"p-1", "p-2", "p-1"},
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetRateAggregates(gomock.Any(), gomock.Eq(&ratingDb.GetRateAggregatesModel{ProviderIds: []string{"p-1", "p-2"}})).
+		Return(&ratingDb.GetRateAggregatesResponse{Aggregates: map[string]ratingDb.GetRateAggregateResponse{
+			"p-1": {Count: 2, Positive: 1, Sum: 7, Average: 3.5, Min: 3, Max: 4},
+		}}, nil)
+
+	response, err := r.ratingService.GetAverageRatingBatch(context.Background(), &model)
+
+	r.Nil(err)
+	r.Equal([]AverageRatingModel{
+		{ProviderId: "p-1", AverageRate: 3.5, Mode: AverageModeMean, RatingCount: 2},
+		{ProviderId: "p-2", AverageRate: 0, Mode: AverageModeMean, RatingCount: 0},
+	}, response.AverageRatings)
+}
+
+func (r *RatingServiceTestSuite) TestGetAverageRatingBatch_DatabaseError_ReturnsError() {
+	model := GetAverageRatingBatchServiceModel{
+		ProviderIds: []string{"p-1"},
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		GetRateAggregates(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("an error occurred"))
+
+	response, err := r.ratingService.GetAverageRatingBatch(context.Background(), &model)
+
+	r.Nil(response)
+	r.Error(err)
+}
+
+func (r *RatingServiceTestSuite) TestSendRatingsBulk_MixedItems_ReturnsStatusPerItem() {
+	model := SendRatingsBulkServiceModel{Ratings: []SendRatingServiceModel{
+		{UserName: "u-1", ProviderId: "p-1", ServiceId: "s-1", Rate: 5},
+		{UserName: "u-2", ProviderId: "p-1", ServiceId: "s-2", Rate: 0},
+		{UserName: "u-3", ProviderId: "p-1", ServiceId: "s-1", Rate: 4},
+		{UserName: "u-4", ProviderId: "p-1", ServiceId: "s-4", Rate: 3},
+	}}
+
+	r.mockEnvironment.EXPECT().GetInt(env.RatingBulkMaxItems, gomock.Any()).Return(500)
+
+	r.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model.Ratings[0])).Return(nil)
+	r.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model.Ratings[1])).Return(errors.New("Rate must be between 1 and 5"))
+	r.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model.Ratings[2])).Return(nil)
+	r.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model.Ratings[3])).Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		AddRates(gomock.Any(), gomock.Eq(&ratingDb.AddRatesModel{Ratings: []ratingDb.AddRatingModel{
+			{UserName: "u-1", ProviderId: "p-1", ServiceId: "s-1", Rate: 5},
+			{UserName: "u-4", ProviderId: "p-1", ServiceId: "s-4", Rate: 3},
+		}})).
+		Return(&ratingDb.AddRatesResponse{Ids: map[string]int64{"s-1": 10}}, nil)
+
+	response, err := r.ratingService.SendRatingsBulk(context.Background(), &model)
+
+	r.Nil(err)
+	r.Equal(1, response.Inserted)
+	r.Equal([]BulkRatingResultModel{
+		{Index: 0, ServiceId: "s-1", Status: BulkStatusInserted},
+		{Index: 1, ServiceId: "s-2", Status: BulkStatusInvalid, Error: "Rate must be between 1 and 5"},
+		{Index: 2, ServiceId: "s-1", Status: BulkStatusDuplicate, Error: "Duplicate ServiceId in request: s-1"},
+		{Index: 3, ServiceId: "s-4", Status: BulkStatusDuplicate, Error: "Rating already exists for ServiceId: s-4"},
+	}, response.Results)
+}
+
+func (r *RatingServiceTestSuite) TestSendRatingsBulk_OversizedItem_RejectsOnlyThatItem() {
+	model := SendRatingsBulkServiceModel{Ratings: []SendRatingServiceModel{
+		{UserName: "u-1", ProviderId: "p-1", ServiceId: "s-1", Rate: 5},
+		{UserName: "u-2", ProviderId: "p-1", ServiceId: strings.Repeat("s", 33), Rate: 4},
+		{UserName: "u-3", ProviderId: "p-1", ServiceId: "s-3", Rate: 3},
+	}}
+
+	r.mockEnvironment.EXPECT().GetInt(env.RatingBulkMaxItems, gomock.Any()).Return(500)
+
+	r.mockRatingDb.
+		EXPECT().
+		AddRates(gomock.Any(), gomock.Eq(&ratingDb.AddRatesModel{Ratings: []ratingDb.AddRatingModel{
+			{UserName: "u-1", ProviderId: "p-1", ServiceId: "s-1", Rate: 5},
+			{UserName: "u-3", ProviderId: "p-1", ServiceId: "s-3", Rate: 3},
+		}})).
+		Return(&ratingDb.AddRatesResponse{Ids: map[string]int64{"s-1": 10, "s-3": 11}}, nil)
+
+	ratingService := NewRatingService(r.mockEnvironment, r.mockLogger, validator.New(), r.mockRatingDb)
+	response, err := ratingService.SendRatingsBulk(context.Background(), &model)
+
+	r.Nil(err)
+	r.Equal(2, response.Inserted)
+	r.Equal(BulkStatusInserted, response.Results[0].Status)
+	r.Equal(BulkStatusInvalid, response.Results[1].Status)
+	r.Contains(response.Results[1].Error, "ServiceId")
+	r.Equal(BulkStatusInserted, response.Results[2].Status)
+}
+
+func (r *RatingServiceTestSuite) TestSendRatingsBulk_TooManyItems_ReturnsError() {
+	model := SendRatingsBulkServiceModel{Ratings: []SendRatingServiceModel{
+		{UserName: "u-1", ProviderId: "p-1", ServiceId: "s-1", Rate: 5},
+		{UserName: "u-2", ProviderId: "p-1", ServiceId: "s-2", Rate: 5},
+	}}
+
+	r.mockEnvironment.EXPECT().GetInt(env.RatingBulkMaxItems, gomock.Any()).Return(1)
+	r.mockLogger.EXPECT().Error(gomock.Any())
+
+	response, err := r.ratingService.SendRatingsBulk(context.Background(), &model)
+
+	r.Nil(response)
+	r.EqualError(err, "Ratings must contain between 1 and 1 items")
+}
+
+func (r *RatingServiceTestSuite) TestSendRatingsBulk_DryRun_ValidatesWithoutInserting() {
+	model := SendRatingsBulkServiceModel{
+		Ratings: []SendRatingServiceModel{
+			{UserName: "u-1", ProviderId: "p-1", ServiceId: "s-1", Rate: 5},
+			{UserName: "u-2", ProviderId: "p-1", ServiceId: "s-2", Rate: 0},
+			{UserName: "u-3", ProviderId: "p-1", ServiceId: "s-3", Rate: 4},
+		},
+		DryRun: true,
+	}
+
+	r.mockEnvironment.EXPECT().GetInt(env.RatingBulkMaxItems, gomock.Any()).Return(500)
+	r.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model.Ratings[0])).Return(nil)
+	r.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model.Ratings[1])).Return(errors.New("Rate must be between 1 and 5"))
+	r.mockValidator.EXPECT().ValidateStruct(gomock.Eq(&model.Ratings[2])).Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		FindRates(gomock.Any(), gomock.Eq(&ratingDb.FindRatesModel{ServiceIds: []string{"s-1", "s-3"}})).
+		Return(&ratingDb.FindRatesResponse{Ids: map[string]int64{"s-3": 7}}, nil)
+
+	response, err := r.ratingService.SendRatingsBulk(context.Background(), &model)
+
+	r.Nil(err)
+	r.Equal(0, response.Inserted)
+	r.Equal([]BulkRatingResultModel{
+		{Index: 0, ServiceId: "s-1", Status: BulkStatusValid},
+		{Index: 1, ServiceId: "s-2", Status: BulkStatusInvalid, Error: "Rate must be between 1 and 5"},
+		{Index: 2, ServiceId: "s-3", Status: BulkStatusDuplicate, Error: "Rating already exists for ServiceId: s-3"},
+	}, response.Results)
+}
+
+func (r *RatingServiceTestSuite) TestSendRatingsBulk_DatabaseError_ReturnsError() {
+	model := SendRatingsBulkServiceModel{Ratings: []SendRatingServiceModel{
+		{UserName: "u-1", ProviderId: "p-1", ServiceId: "s-1", Rate: 5},
+	}}
+
+	r.mockEnvironment.EXPECT().GetInt(env.RatingBulkMaxItems, gomock.Any()).Return(500)
+	r.mockValidator.EXPECT().ValidateStruct(gomock.Any()).Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		AddRates(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("an error occurred"))
+
+	response, err := r.ratingService.SendRatingsBulk(context.Background(), &model)
+
+	r.Nil(response)
+	r.Error(err)
+}
+
+func (r *RatingServiceTestSuite) TestExportRatings_Csv_WritesHeaderAndRows() {
+	model := ExportRatingsServiceModel{
+		ProviderId: "test-1",
+		Format:     ExportFormatCsv,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	cursor := ratingDb.NewMockIRateCursor(gomock.NewController(r.T()))
+	gomock.InOrder(
+		cursor.EXPECT().Next().Return(true),
+		cursor.EXPECT().Record().Return(&ratingDb.RateRecord{
+			Id: 1, UserName: "u-1", ProviderId: "test-1", ServiceId: "s-1", Rate: 5, Comment: "Great, fast",
+			CreatedDate: time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC),
+		}, nil),
+		cursor.EXPECT().Next().Return(false),
+	)
+	cursor.EXPECT().Err().Return(nil)
+	cursor.EXPECT().Close().Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		ExportRates(gomock.Any(), gomock.Eq(&ratingDb.ExportRatesModel{ProviderId: "test-1"})).
+		Return(cursor, nil)
+
+	var output bytes.Buffer
+	err := r.ratingService.ExportRatings(context.Background(), &model, &output)
+
+	r.Nil(err)
+	r.Equal("id,username,provider_id,service_id,rate,title,comment,created_date\n"+
+		"1,u-1,test-1,s-1,5,,\"Great, fast\",2023-03-01T10:00:00Z\n", output.String())
+}
+
+func (r *RatingServiceTestSuite) TestExportRatings_Csv_EscapesFormulas() {
+	model := ExportRatingsServiceModel{
+		ProviderId: "test-1",
+		Format:     ExportFormatCsv,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	cursor := ratingDb.NewMockIRateCursor(gomock.NewController(r.T()))
+	gomock.InOrder(
+		cursor.EXPECT().Next().Return(true),
+		cursor.EXPECT().Record().Return(&ratingDb.RateRecord{
+			Id: 1, UserName: "@u-1", ProviderId: "test-1", ServiceId: "s-1", Rate: 1, Title: "=HYPERLINK(\"x\")", Comment: "-1+1",
+		}, nil),
+		cursor.EXPECT().Next().Return(true),
+		cursor.EXPECT().Record().Return(&ratingDb.RateRecord{
+			Id: 2, UserName: "u-2", ProviderId: "test-1", ServiceId: "@s-2", Rate: 4, Title: "+1", Comment: "\tTabbed",
+		}, nil),
+		cursor.EXPECT().Next().Return(true),
+		cursor.EXPECT().Record().Return(&ratingDb.RateRecord{
+			Id: 3, UserName: "u-3", ProviderId: "=test-1", ServiceId: "s-3", Rate: 5, Title: "Fine = good", Comment: "\rx",
+		}, nil),
+		cursor.EXPECT().Next().Return(true),
+		cursor.EXPECT().Record().Return(&ratingDb.RateRecord{
+			Id: 4, UserName: "'u-4'", ProviderId: "test-1", ServiceId: "s-4", Rate: 5, Title: "'-) great", Comment: "it's fine",
+		}, nil),
+		cursor.EXPECT().Next().Return(false),
+	)
+	cursor.EXPECT().Err().Return(nil)
+	cursor.EXPECT().Close().Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		ExportRates(gomock.Any(), gomock.Eq(&ratingDb.ExportRatesModel{ProviderId: "test-1"})).
+		Return(cursor, nil)
+
+	var output bytes.Buffer
+	err := r.ratingService.ExportRatings(context.Background(), &model, &output)
+
+	r.Nil(err)
+	r.Equal("id,username,provider_id,service_id,rate,title,comment,created_date\n"+
+		"1,'@u-1,test-1,s-1,1,\"'=HYPERLINK(\"\"x\"\")\",'-1+1,\n"+
+		"2,u-2,test-1,'@s-2,4,'+1,'\tTabbed,\n"+
+		"3,u-3,'=test-1,s-3,5,Fine = good,\"'\rx\",\n"+
+		"4,'u-4',test-1,s-4,5,''-) great,it's fine,\n", output.String())
+}
+
+func (r *RatingServiceTestSuite) TestExportRatings_Csv_NoRows_WritesHeaderOnly() {
+	model := ExportRatingsServiceModel{
+		ProviderId: "test-1",
+		Format:     ExportFormatCsv,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	cursor := ratingDb.NewMockIRateCursor(gomock.NewController(r.T()))
+	cursor.EXPECT().Next().Return(false)
+	cursor.EXPECT().Err().Return(nil)
+	cursor.EXPECT().Close().Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		ExportRates(gomock.Any(), gomock.Eq(&ratingDb.ExportRatesModel{ProviderId: "test-1"})).
+		Return(cursor, nil)
+
+	var output bytes.Buffer
+	err := r.ratingService.ExportRatings(context.Background(), &model, &output)
+
+	r.Nil(err)
+	r.Equal("id,username,provider_id,service_id,rate,title,comment,created_date\n", output.String())
+}
+
+func (r *RatingServiceTestSuite) TestExportRatings_Ndjson_WritesOneObjectPerLine() {
+	model := ExportRatingsServiceModel{
+		Format: ExportFormatNdjson,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	cursor := ratingDb.NewMockIRateCursor(gomock.NewController(r.T()))
+	gomock.InOrder(
+		cursor.EXPECT().Next().Return(true),
+		cursor.EXPECT().Record().Return(&ratingDb.RateRecord{Id: 1, ServiceId: "s-1", Rate: 5}, nil),
+		cursor.EXPECT().Next().Return(true),
+		cursor.EXPECT().Record().Return(&ratingDb.RateRecord{Id: 2, ServiceId: "s-2", Rate: 3}, nil),
+		cursor.EXPECT().Next().Return(false),
+	)
+	cursor.EXPECT().Err().Return(nil)
+	cursor.EXPECT().Close().Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		ExportRates(gomock.Any(), gomock.Any()).
+		Return(cursor, nil)
+
+	var output bytes.Buffer
+	err := r.ratingService.ExportRatings(context.Background(), &model, &output)
+
+	r.Nil(err)
+	lines := bytes.Split(bytes.TrimSpace(output.Bytes()), []byte("\n"))
+	r.Len(lines, 2)
+	r.Contains(string(lines[0]), `"ServiceId":"s-1"`)
+	r.Contains(string(lines[1]), `"ServiceId":"s-2"`)
+}
+
+func (r *RatingServiceTestSuite) TestExportRatings_DatabaseError_WritesNothing() {
+	model := ExportRatingsServiceModel{
+		Format: ExportFormatCsv,
+	}
+
+	r.mockValidator.
+		EXPECT().
+		ValidateStruct(gomock.Eq(&model)).
+		Return(nil)
+
+	r.mockRatingDb.
+		EXPECT().
+		ExportRates(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("an error occurred"))
 
-	go r.ratingService.GetAverageRating(ch, &model)
-	response := <-ch
+	var output bytes.Buffer
+	err := r.ratingService.ExportRatings(context.Background(), &model, &output)
 
-	r.NotNil(response.Error)
-	r.EqualError(response.Error, "No ratings found for ProviderId: "+model.ProviderId)
+	r.Error(err)
+	r.Zero(output.Len())
 }
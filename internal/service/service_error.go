@@ -0,0 +1,40 @@
+package service
+
+import "errors"
+
+// Kinds of failures returned by the services. An error wrapped with Wrap keeps its own message
+// and matches its kind with errors.Is, so the API can map it to a status code in one place.
+var (
+	ErrValidation  = errors.New("validation failed")
+	ErrNotFound    = errors.New("not found")
+	ErrForbidden   = errors.New("forbidden")
+	ErrDuplicate   = errors.New("duplicate")
+	ErrUnavailable = errors.New("storage unavailable")
+)
+
+type serviceError struct {
+	kind error
+	err  error
+}
+
+// Wrap
+// Returns err marked as the given kind, or nil when err is nil.
+func Wrap(kind error, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &serviceError{kind: kind, err: err}
+}
+
+func (e *serviceError) Error() string {
+	return e.err.Error()
+}
+
+func (e *serviceError) Unwrap() error {
+	return e.err
+}
+
+func (e *serviceError) Is(target error) bool {
+	return target == e.kind
+}
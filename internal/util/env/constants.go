@@ -5,7 +5,60 @@ const (
 	AppEnvironment = "APP_ENVIRONMENT"
 	AppName        = "APP_NAME"
 	AppHost        = "APP_HOST"
+	// Port the HTTP server listens on, 8080 when empty.
+	AppPort = "PORT"
+	// Comma separated IPs or CIDRs of reverse proxies whose X-Forwarded-For header is trusted, none when empty.
+	AppTrustedProxies = "APP_TRUSTED_PROXIES"
+)
+
+// Authentication
+const (
+	// Shared secret of HS256 signed JWTs.
+	AuthJwtSecret = "AUTH_JWT_SECRET"
+	// Path of a JWKS file with the public keys of RS256 signed JWTs.
+	AuthJwtJwksFile = "AUTH_JWT_JWKS_FILE"
+	// Required iss and aud claims of JWTs, not checked when empty.
+	AuthJwtIssuer   = "AUTH_JWT_ISSUER"
+	AuthJwtAudience = "AUTH_JWT_AUDIENCE"
+)
+
+// Rating
+const (
+	RatingCriteria            = "RATING_CRITERIA"
+	RatingBayesianPriorMean   = "RATING_BAYESIAN_PRIOR_MEAN"
+	RatingBayesianPriorWeight = "RATING_BAYESIAN_PRIOR_WEIGHT"
+	RatingDecayHalfLife       = "RATING_DECAY_HALF_LIFE"
+	RatingBulkMaxItems        = "RATING_BULK_MAX_ITEMS"
+)
+
+// Idempotency
+const (
+	// How long the response to a request made with an Idempotency-Key is replayed, e.g. "24h".
+	IdempotencyKeyTtl = "IDEMPOTENCY_KEY_TTL"
+	// How long a request holds its Idempotency-Key before a retry may take it over, e.g. "1m".
+	// Covers requests that never finish because the process crashed.
+	IdempotencyLeaseTimeout = "IDEMPOTENCY_LEASE_TIMEOUT"
+)
+
+// Rate limiting
+const (
+	// Token bucket limits per caller and route as "<requests>/<period>", e.g. "30/1m", or "off".
+	RateLimitRatingAdd    = "RATE_LIMIT_RATING_ADD"
+	RateLimitRatingBulk   = "RATE_LIMIT_RATING_BULK"
+	RateLimitRatingWrite  = "RATE_LIMIT_RATING_WRITE"
+	RateLimitRatingRead   = "RATE_LIMIT_RATING_READ"
+	RateLimitRatingExport = "RATE_LIMIT_RATING_EXPORT"
+	// Most token buckets kept in memory, the least recently used one is dropped for a new caller.
+	RateLimitMaxBuckets = "RATE_LIMIT_MAX_BUCKETS"
 )
 
 // Database
-const PostgresqlConnectionString = "POSTGRESQL_CONNECTION_STRING"
+const (
+	PostgresqlConnectionString = "POSTGRESQL_CONNECTION_STRING"
+	PostgresqlMaxOpenConns     = "POSTGRESQL_MAX_OPEN_CONNS"
+	PostgresqlMaxIdleConns     = "POSTGRESQL_MAX_IDLE_CONNS"
+	PostgresqlConnMaxLifetime  = "POSTGRESQL_CONN_MAX_LIFETIME"
+	PostgresqlConnMaxIdleTime  = "POSTGRESQL_CONN_MAX_IDLE_TIME"
+	// Applies pending schema migrations when the API server starts.
+	PostgresqlAutoMigrate = "POSTGRESQL_AUTO_MIGRATE"
+)
@@ -3,6 +3,8 @@ package env
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -10,6 +12,10 @@ import (
 type IEnvironment interface {
 	Init()
 	Get(key string) string
+	GetInt(key string, defaultValue int) int
+	GetFloat(key string, defaultValue float64) float64
+	GetDuration(key string, defaultValue time.Duration) time.Duration
+	GetBool(key string, defaultValue bool) bool
 	Set(key string, value string) error
 	GetHostname() (string, error)
 }
@@ -44,6 +50,50 @@ func (e *Environment) Get(key string) string {
 	return os.Getenv(key)
 }
 
+// GetInt
+// Returns the variable parsed as an int, or defaultValue when it is unset or malformed.
+func (e *Environment) GetInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+// GetFloat
+// Returns the variable parsed as a float64, or defaultValue when it is unset or malformed.
+func (e *Environment) GetFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+// GetDuration
+// Returns the variable parsed as a time.Duration (e.g. "5m"), or defaultValue when it is unset or malformed.
+func (e *Environment) GetDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+// GetBool
+// Returns the variable parsed as a bool (e.g. "true", "1"), or defaultValue when it is unset or malformed.
+func (e *Environment) GetBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
 func (e *Environment) Set(key string, value string) error {
 	return os.Setenv(key, value)
 }
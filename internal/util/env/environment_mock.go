@@ -6,6 +6,7 @@ package env
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -47,6 +48,48 @@ func (mr *MockIEnvironmentMockRecorder) Get(key interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIEnvironment)(nil).Get), key)
 }
 
+// GetBool mocks base method.
+func (m *MockIEnvironment) GetBool(key string, defaultValue bool) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBool", key, defaultValue)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// GetBool indicates an expected call of GetBool.
+func (mr *MockIEnvironmentMockRecorder) GetBool(key, defaultValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBool", reflect.TypeOf((*MockIEnvironment)(nil).GetBool), key, defaultValue)
+}
+
+// GetDuration mocks base method.
+func (m *MockIEnvironment) GetDuration(key string, defaultValue time.Duration) time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuration", key, defaultValue)
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetDuration indicates an expected call of GetDuration.
+func (mr *MockIEnvironmentMockRecorder) GetDuration(key, defaultValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuration", reflect.TypeOf((*MockIEnvironment)(nil).GetDuration), key, defaultValue)
+}
+
+// GetFloat mocks base method.
+func (m *MockIEnvironment) GetFloat(key string, defaultValue float64) float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFloat", key, defaultValue)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetFloat indicates an expected call of GetFloat.
+func (mr *MockIEnvironmentMockRecorder) GetFloat(key, defaultValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFloat", reflect.TypeOf((*MockIEnvironment)(nil).GetFloat), key, defaultValue)
+}
+
 // GetHostname mocks base method.
 func (m *MockIEnvironment) GetHostname() (string, error) {
 	m.ctrl.T.Helper()
@@ -62,6 +105,20 @@ func (mr *MockIEnvironmentMockRecorder) GetHostname() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostname", reflect.TypeOf((*MockIEnvironment)(nil).GetHostname))
 }
 
+// GetInt mocks base method.
+func (m *MockIEnvironment) GetInt(key string, defaultValue int) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInt", key, defaultValue)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetInt indicates an expected call of GetInt.
+func (mr *MockIEnvironmentMockRecorder) GetInt(key, defaultValue interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInt", reflect.TypeOf((*MockIEnvironment)(nil).GetInt), key, defaultValue)
+}
+
 // Init mocks base method.
 func (m *MockIEnvironment) Init() {
 	m.ctrl.T.Helper()
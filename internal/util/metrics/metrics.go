@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of HTTP requests by route, method and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Duration of database queries by query name.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"query"})
+
+	dbQueryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "Number of failed database queries by query name.",
+	}, []string{"query"})
+
+	ratingsAdded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ratings_added_total",
+		Help: "Number of ratings added by rate value.",
+	}, []string{"rate"})
+
+	poolsMutex sync.Mutex
+	pools      = make(map[string]prometheus.Collector)
+)
+
+// Handler
+// Returns the HTTP handler exposing every metric in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveRequest
+// Records a served HTTP request. route is the route pattern, not the request path, to keep the label set small.
+func ObserveRequest(route string, method string, status int, elapsed time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(route, method, code).Inc()
+	httpRequestDuration.WithLabelValues(route, method, code).Observe(elapsed.Seconds())
+}
+
+// ObserveQuery
+// Records a database query started at start, counting it as failed when failed is set.
+func ObserveQuery(query string, start time.Time, failed bool) {
+	dbQueryDuration.WithLabelValues(query).Observe(time.Since(start).Seconds())
+	if failed {
+		dbQueryErrors.WithLabelValues(query).Inc()
+	}
+}
+
+// RatingAdded
+// Counts an added rating by its rate value.
+func RatingAdded(rate int) {
+	ratingsAdded.WithLabelValues(strconv.Itoa(rate)).Inc()
+}
+
+// RegisterPool
+// Exports the connection pool stats of connection labelled with name.
+// A pool registered again under the same name replaces the stats of the earlier one, which may have been closed.
+func RegisterPool(name string, connection *sql.DB) {
+	poolsMutex.Lock()
+	defer poolsMutex.Unlock()
+
+	if previous, ok := pools[name]; ok {
+		prometheus.Unregister(previous)
+	}
+
+	collector := collectors.NewDBStatsCollector(connection, name)
+	if err := prometheus.Register(collector); err != nil {
+		panic("Panicked while registering connection pool metrics: " + err.Error())
+	}
+	pools[name] = collector
+}
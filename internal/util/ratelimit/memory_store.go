@@ -0,0 +1,130 @@
+package ratelimit
+
+import (
+	"container/list"
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval
+// How often MemoryStore drops buckets that have filled up again, which behave like missing ones.
+const sweepInterval = time.Minute
+
+type MemoryStore struct {
+	mutex      sync.Mutex
+	buckets    map[string]*list.Element
+	recent     *list.List
+	maxBuckets int
+	now        func() time.Time
+	lastSweep  time.Time
+}
+
+type bucket struct {
+	key      string
+	tokens   float64
+	capacity float64
+	rate     float64
+	updated  time.Time
+}
+
+// NewMemoryStore
+// Returns a new MemoryStore keeping up to maxBuckets token buckets in the process, so every instance of the API
+// limits on its own. When it is full, the least recently used bucket is dropped for a new key.
+func NewMemoryStore(maxBuckets int) IRateLimitStore {
+	if maxBuckets < 1 {
+		maxBuckets = 1
+	}
+
+	return &MemoryStore{
+		buckets:    make(map[string]*list.Element),
+		recent:     list.New(),
+		maxBuckets: maxBuckets,
+		now:        time.Now,
+	}
+}
+
+// Take
+// Takes a token from the bucket of key, creating a full bucket for a new key.
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (*Result, error) {
+	if limit.Requests <= 0 {
+		return &Result{Allowed: true}, nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b := s.bucket(key, limit, now)
+	b.capacity = float64(limit.Requests)
+	b.rate = float64(limit.Requests) / limit.Period.Seconds()
+	b.refill(now)
+
+	result := Result{Limit: limit.Requests}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / b.rate)
+	}
+	result.Remaining = int(math.Floor(b.tokens))
+	result.Reset = seconds((b.capacity - b.tokens) / b.rate)
+
+	return &result, nil
+}
+
+// bucket
+// Returns the bucket of key marked as most recently used, adding a full one for a new key.
+func (s *MemoryStore) bucket(key string, limit Limit, now time.Time) *bucket {
+	if element, ok := s.buckets[key]; ok {
+		s.recent.MoveToFront(element)
+		return element.Value.(*bucket)
+	}
+
+	for len(s.buckets) >= s.maxBuckets {
+		s.remove(s.recent.Back())
+	}
+
+	b := &bucket{key: key, tokens: float64(limit.Requests), capacity: float64(limit.Requests), updated: now}
+	s.buckets[key] = s.recent.PushFront(b)
+
+	return b
+}
+
+// sweep
+// Drops full buckets once per sweepInterval.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for element := s.recent.Front(); element != nil; {
+		next := element.Next()
+		b := element.Value.(*bucket)
+		b.refill(now)
+		if b.tokens >= b.capacity {
+			s.remove(element)
+		}
+		element = next
+	}
+}
+
+func (s *MemoryStore) remove(element *list.Element) {
+	delete(s.buckets, element.Value.(*bucket).key)
+	s.recent.Remove(element)
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.capacity, b.tokens+elapsed*b.rate)
+	}
+	b.updated = now
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"rating-api/internal/util/env"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IRateLimitStore
+// Keeps a token bucket per key. NewMemoryStore keeps them in the process, a store shared by every instance
+// of the API can be plugged in to enforce limits across instances.
+type IRateLimitStore interface {
+	Take(ctx context.Context, key string, limit Limit) (*Result, error)
+}
+
+// Limit
+// Token bucket holding up to Requests tokens, refilled at Requests per Period. A zero Limit does not limit.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Result
+// Outcome of taking a token. RetryAfter is the time until the next token when the request is not allowed,
+// Reset the time until the bucket is full again.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	Reset      time.Duration
+}
+
+// Off
+// Value of a rate limit variable that turns the limit off.
+const Off = "off"
+
+var errInvalidLimit = errors.New(`rate limit must look like "30/1m"`)
+
+// ParseLimit
+// Parses "<requests>/<period>" such as "30/1m", or Off.
+func ParseLimit(value string) (Limit, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, Off) {
+		return Limit{}, nil
+	}
+
+	requests, period, found := strings.Cut(value, "/")
+	if !found {
+		return Limit{}, fmt.Errorf("%w, got %q", errInvalidLimit, value)
+	}
+
+	limit := Limit{}
+	var err error
+	if limit.Requests, err = strconv.Atoi(requests); err != nil || limit.Requests < 1 {
+		return Limit{}, fmt.Errorf("%w, got %q", errInvalidLimit, value)
+	}
+	if limit.Period, err = time.ParseDuration(period); err != nil || limit.Period <= 0 {
+		return Limit{}, fmt.Errorf("%w, got %q", errInvalidLimit, value)
+	}
+
+	return limit, nil
+}
+
+// LimitOf
+// Returns the limit set by the variable, or defaultLimit when it is unset or malformed.
+func LimitOf(environment env.IEnvironment, key string, defaultLimit Limit) Limit {
+	limit, err := ParseLimit(environment.Get(key))
+	if err != nil {
+		return defaultLimit
+	}
+
+	return limit
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../internal/util/ratelimit/ratelimit.go
+
+// Package ratelimit is a generated GoMock package.
+package ratelimit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRateLimitStore is a mock of IRateLimitStore interface.
+type MockIRateLimitStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRateLimitStoreMockRecorder
+}
+
+// MockIRateLimitStoreMockRecorder is the mock recorder for MockIRateLimitStore.
+type MockIRateLimitStoreMockRecorder struct {
+	mock *MockIRateLimitStore
+}
+
+// NewMockIRateLimitStore creates a new mock instance.
+func NewMockIRateLimitStore(ctrl *gomock.Controller) *MockIRateLimitStore {
+	mock := &MockIRateLimitStore{ctrl: ctrl}
+	mock.recorder = &MockIRateLimitStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRateLimitStore) EXPECT() *MockIRateLimitStoreMockRecorder {
+	return m.recorder
+}
+
+// Take mocks base method.
+func (m *MockIRateLimitStore) Take(ctx context.Context, key string, limit Limit) (*Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, key, limit)
+	ret0, _ := ret[0].(*Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockIRateLimitStoreMockRecorder) Take(ctx, key, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockIRateLimitStore)(nil).Take), ctx, key, limit)
+}
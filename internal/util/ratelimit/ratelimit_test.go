@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+}
+
+// Run suite.
+func TestRateLimit(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
+
+func (r *RateLimitTestSuite) TestParseLimit() {
+	tests := []struct {
+		value string
+		limit Limit
+		fails bool
+	}{
+		{value: "30/1m", limit: Limit{Requests: 30, Period: time.Minute}},
+		{value: " 5/10s ", limit: Limit{Requests: 5, Period: 10 * time.Second}},
+		{value: "off", limit: Limit{}},
+		{value: "OFF", limit: Limit{}},
+		{value: "", fails: true},
+		{value: "30", fails: true},
+		{value: "x/1m", fails: true},
+		{value: "0/1m", fails: true},
+		{value: "-1/1m", fails: true},
+		{value: "30/x", fails: true},
+		{value: "30/0s", fails: true},
+		{value: "30/-1m", fails: true},
+	}
+
+	for _, test := range tests {
+		r.Run(test.value, func() {
+			limit, err := ParseLimit(test.value)
+
+			if test.fails {
+				r.ErrorIs(err, errInvalidLimit)
+				return
+			}
+			r.Nil(err)
+			r.Equal(test.limit, limit)
+		})
+	}
+}
+
+func (r *RateLimitTestSuite) TestMemoryStore_EmptyBucket_RefillsOverTime() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore(10).(*MemoryStore)
+	store.now = func() time.Time { return now }
+	limit := Limit{Requests: 2, Period: 2 * time.Second}
+
+	steps := []struct {
+		after      time.Duration
+		allowed    bool
+		remaining  int
+		retryAfter time.Duration
+	}{
+		{allowed: true, remaining: 1},
+		{allowed: true, remaining: 0},
+		{allowed: false, remaining: 0, retryAfter: time.Second},
+		{after: 500 * time.Millisecond, allowed: false, remaining: 0, retryAfter: 500 * time.Millisecond},
+		{after: 500 * time.Millisecond, allowed: true, remaining: 0},
+		{after: 10 * time.Second, allowed: true, remaining: 1},
+	}
+
+	for i, step := range steps {
+		now = now.Add(step.after)
+		result, err := store.Take(context.Background(), "caller", limit)
+
+		r.Nil(err)
+		r.Equal(step.allowed, result.Allowed, "step %d", i)
+		r.Equal(step.remaining, result.Remaining, "step %d", i)
+		r.Equal(step.retryAfter, result.RetryAfter, "step %d", i)
+		r.Equal(2, result.Limit, "step %d", i)
+	}
+}
+
+func (r *RateLimitTestSuite) TestMemoryStore_Full_DropsLeastRecentlyUsedBucket() {
+	store := NewMemoryStore(2).(*MemoryStore)
+	limit := Limit{Requests: 1, Period: time.Hour}
+
+	for _, key := range []string{"a", "b", "a", "c"} {
+		_, err := store.Take(context.Background(), key, limit)
+		r.Nil(err)
+	}
+
+	r.Len(store.buckets, 2)
+	r.Contains(store.buckets, "a")
+	r.Contains(store.buckets, "c")
+}
+
+func (r *RateLimitTestSuite) TestMemoryStore_ZeroLimit_AllowsWithoutBucket() {
+	store := NewMemoryStore(1).(*MemoryStore)
+
+	result, err := store.Take(context.Background(), "caller", Limit{})
+
+	r.Nil(err)
+	r.True(result.Allowed)
+	r.Empty(store.buckets)
+}
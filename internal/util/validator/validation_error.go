@@ -0,0 +1,91 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Violation
+// A field failing a validation rule, e.g. Rate failing lte with Param 5.
+type Violation struct {
+	Field   string `json:"Field"`
+	Rule    string `json:"Rule"`
+	Param   string `json:"Param"`
+	Message string `json:"Message"`
+}
+
+type ValidationError struct {
+	Violations []Violation
+}
+
+func newValidationError(fieldErrs validator.ValidationErrors) *ValidationError {
+	validationErr := ValidationError{Violations: make([]Violation, 0, len(fieldErrs))}
+	for _, fieldErr := range fieldErrs {
+		validationErr.Violations = append(validationErr.Violations, Violation{
+			Field:   violationField(fieldErr),
+			Rule:    fieldErr.Tag(),
+			Param:   fieldErr.Param(),
+			Message: violationMessage(fieldErr),
+		})
+	}
+
+	return &validationErr
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Message)
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// violationField
+// Returns the path of the field below the validated struct, e.g. "Ratings[1].Rate".
+func violationField(fieldErr validator.FieldError) string {
+	namespace := fieldErr.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+
+	return fieldErr.Field()
+}
+
+func violationMessage(fieldErr validator.FieldError) string {
+	field := fieldErr.Field()
+	switch fieldErr.Tag() {
+	case "required":
+		return field + " is required"
+	case "gte", "min":
+		return fmt.Sprintf("%s must be at least %s%s", field, fieldErr.Param(), violationUnit(fieldErr))
+	case "lte", "max":
+		return fmt.Sprintf("%s must be at most %s%s", field, fieldErr.Param(), violationUnit(fieldErr))
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s%s", field, fieldErr.Param(), violationUnit(fieldErr))
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s%s", field, fieldErr.Param(), violationUnit(fieldErr))
+	case "len":
+		return fmt.Sprintf("%s must be exactly %s%s", field, fieldErr.Param(), violationUnit(fieldErr))
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.ReplaceAll(fieldErr.Param(), " ", ", "))
+	}
+
+	return fmt.Sprintf("%s failed the %s rule", field, fieldErr.Tag())
+}
+
+// violationUnit
+// Size rules count characters of strings and items of collections.
+func violationUnit(fieldErr validator.FieldError) string {
+	switch fieldErr.Kind() {
+	case reflect.String:
+		return " characters long"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	}
+
+	return ""
+}
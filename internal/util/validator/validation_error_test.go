@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type testRating struct {
+	UserName string `json:"UserName" validate:"required,max=4"`
+	Rate     int    `json:"Rate" validate:"gte=1,lte=5"`
+	Mode     string `validate:"omitempty,oneof=mean bayesian"`
+}
+
+type testRatingsBulk struct {
+	Ratings []testRating `json:"Ratings" validate:"required,min=1,dive"`
+}
+
+type ValidationErrorTestSuite struct {
+	suite.Suite
+	validatr IValidator
+}
+
+// Run suite.
+func TestValidationError(t *testing.T) {
+	suite.Run(t, new(ValidationErrorTestSuite))
+}
+
+// Runs before each test in the suite.
+func (v *ValidationErrorTestSuite) SetupTest() {
+	v.validatr = New()
+}
+
+func (v *ValidationErrorTestSuite) TestValidateStruct_Violations() {
+	tests := []struct {
+		name       string
+		model      interface{}
+		violations []Violation
+	}{
+		{
+			name:  "lte on Rate",
+			model: &testRating{UserName: "u-1", Rate: 6},
+			violations: []Violation{
+				{Field: "Rate", Rule: "lte", Param: "5", Message: "Rate must be at most 5"},
+			},
+		},
+		{
+			name:  "required",
+			model: &testRating{Rate: 5},
+			violations: []Violation{
+				{Field: "UserName", Rule: "required", Param: "", Message: "UserName is required"},
+			},
+		},
+		{
+			name:  "max on a string",
+			model: &testRating{UserName: "user-1", Rate: 5},
+			violations: []Violation{
+				{Field: "UserName", Rule: "max", Param: "4", Message: "UserName must be at most 4 characters long"},
+			},
+		},
+		{
+			name:  "oneof on a field without json tag",
+			model: &testRating{UserName: "u-1", Rate: 5, Mode: "median"},
+			violations: []Violation{
+				{Field: "Mode", Rule: "oneof", Param: "mean bayesian", Message: "Mode must be one of: mean, bayesian"},
+			},
+		},
+		{
+			name:  "nested slice item",
+			model: &testRatingsBulk{Ratings: []testRating{{UserName: "u-1", Rate: 5}, {UserName: "u-2", Rate: 0}}},
+			violations: []Violation{
+				{Field: "Ratings[1].Rate", Rule: "gte", Param: "1", Message: "Rate must be at least 1"},
+			},
+		},
+		{
+			name:  "min on a slice",
+			model: &testRatingsBulk{Ratings: []testRating{}},
+			violations: []Violation{
+				{Field: "Ratings", Rule: "min", Param: "1", Message: "Ratings must be at least 1 items"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		err := v.validatr.ValidateStruct(test.model)
+
+		var validationErr *ValidationError
+		v.Require().ErrorAs(err, &validationErr, test.name)
+		v.Equal(test.violations, validationErr.Violations, test.name)
+	}
+}
+
+func (v *ValidationErrorTestSuite) TestValidateStruct_Error_JoinsMessages() {
+	err := v.validatr.ValidateStruct(&testRating{Rate: 9})
+
+	v.EqualError(err, "UserName is required; Rate must be at most 5")
+}
+
+func (v *ValidationErrorTestSuite) TestValidateStruct_Valid_ReturnsNil() {
+	v.Nil(v.validatr.ValidateStruct(&testRating{UserName: "u-1", Rate: 3}))
+}
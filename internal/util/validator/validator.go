@@ -1,6 +1,10 @@
 package validator
 
 import (
+	"errors"
+	"reflect"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -13,13 +17,31 @@ type Validator struct {
 }
 
 // New
-// Returns a new Validator.
+// Returns a new Validator naming fields by their json tag, or their Go name when they have none.
 func New() IValidator {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
 	return &Validator{
-		validate: validator.New(),
+		validate: validate,
 	}
 }
 
+// ValidateStruct
+// Returns a *ValidationError listing every violation when s is invalid.
 func (v *Validator) ValidateStruct(s interface{}) error {
-	return v.validate.Struct(s)
+	err := v.validate.Struct(s)
+
+	var fieldErrs validator.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		return newValidationError(fieldErrs)
+	}
+
+	return err
 }
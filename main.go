@@ -1,18 +1,38 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
 	"rating-api/docs"
 	"rating-api/internal/api"
 	"rating-api/internal/api/controller/v1/health"
 	"rating-api/internal/api/controller/v1/rating"
+	"rating-api/internal/cli"
+	"rating-api/internal/data/database"
+	apiKeyDb "rating-api/internal/data/database/apikey"
+	idempotencyDb "rating-api/internal/data/database/idempotency"
+	"rating-api/internal/data/database/migration"
+	ratingDb "rating-api/internal/data/database/rating"
+	apiKeyService "rating-api/internal/service/apikey"
+	idempotencyService "rating-api/internal/service/idempotency"
+	ratingService "rating-api/internal/service/rating"
 	"rating-api/internal/util/env"
 	"rating-api/internal/util/logger"
+	"rating-api/internal/util/metrics"
+	"rating-api/internal/util/ratelimit"
 	"rating-api/internal/util/validator"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/swaggo/gin-swagger/swaggerFiles"
+	"go.uber.org/zap"
 )
 
 //	@title			Rating API
@@ -29,28 +49,103 @@ import (
 //	@accept			json
 //	@produce		json
 //	@schemes		http https
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+//	@description				JWT as "Bearer <token>".
+//	@securityDefinitions.apikey	ApiKeyAuth
+//	@in							header
+//	@name						X-Api-Key
 func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// run
+// Starts the API server, or runs the command given as the first argument and returns its exit code.
+func run(args []string) int {
 	environment := env.New()
 	environment.Init()
 	loggr := logger.New(environment)
 	defer loggr.Sync()
 	validatr := validator.New()
+	connection := database.Open(loggr, environment)
+	defer connection.Close()
+	db := ratingDb.NewRatingDb(loggr, validatr, environment, connection)
+	defer db.Close()
+
+	if len(args) > 0 {
+		return runCommand(args, environment, loggr, validatr, connection, db)
+	}
+
+	if environment.GetBool(env.PostgresqlAutoMigrate, false) {
+		if err := autoMigrate(loggr, environment); err != nil {
+			return 1
+		}
+	}
+
+	idempotencyKeys := idempotencyDb.NewIdempotencyDb(loggr, validatr, environment, connection)
+	defer idempotencyKeys.Close()
+	apiKeys := apiKeyDb.NewApiKeyDb(loggr, validatr, environment, connection)
+	defer apiKeys.Close()
 
 	router := gin.New()
+	if err := router.SetTrustedProxies(trustedProxies(environment)); err != nil {
+		loggr.Error(err.Error())
+		return 1
+	}
 	router.Use(api.LoggingMiddleware(loggr))
-	addRoutes(router, environment, loggr, validatr)
+	router.Use(api.MetricsMiddleware())
+	router.Use(api.JwtMiddleware(environment, loggr))
+	router.Use(api.ApiKeyMiddleware(apiKeyService.NewApiKeyService(environment, loggr, validatr, apiKeys)))
+	addRoutes(router, environment, loggr, validatr, db, idempotencyKeys)
 	addSwagger(router, environment)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// listen and serve on 0.0.0.0:$PORT, 8080 by default (for windows "localhost:8080")
+	if err := serve(router, environment, loggr); err != nil {
+		return 1
+	}
 
-	// listen and serve on 0.0.0.0:8080 (for windows "localhost:8080")
-	router.Run()
+	return 0
 }
 
-func addRoutes(router *gin.Engine, environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator) {
+func runCommand(args []string, environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, connection *sql.DB, db ratingDb.IRatingDb) int {
+	switch args[0] {
+	case "import":
+		service := ratingService.NewRatingService(environment, loggr, validatr, db)
+		return cli.Import(args[1:], environment, service, os.Stdout)
+	case "apikey":
+		apiKeys := apiKeyDb.NewApiKeyDb(loggr, validatr, environment, connection)
+		defer apiKeys.Close()
+		return cli.ApiKey(args[1:], apiKeyService.NewApiKeyService(environment, loggr, validatr, apiKeys), os.Stdout)
+	case "migrate":
+		migrationDb := migration.NewMigrationDb(loggr, environment)
+		defer migrationDb.Close()
+		return cli.Migrate(args[1:], migrationDb, os.Stdout)
+	default:
+		fmt.Fprintln(os.Stderr, "usage: rating-api [import --file ratings.csv [--dry-run] | migrate up|down [--steps n]|status | apikey create|revoke|list]")
+		return 2
+	}
+}
+
+// autoMigrate
+// Applies pending migrations before the server starts accepting requests.
+func autoMigrate(loggr logger.ILogger, environment env.IEnvironment) error {
+	migrationDb := migration.NewMigrationDb(loggr, environment)
+	defer migrationDb.Close()
+
+	_, err := migrationDb.Up(context.Background())
+	return err
+}
+
+func addRoutes(router *gin.Engine, environment env.IEnvironment, loggr logger.ILogger, validatr validator.IValidator, db ratingDb.IRatingDb, idempotencyKeys idempotencyDb.IIdempotencyDb) {
 	api := router.Group("api")
 	health.NewHealthController().RegisterRoutes(api)
 
 	v1 := api.Group("v1")
-	rating.NewRatingController(environment, loggr, validatr, nil).RegisterRoutes(v1)
+	service := ratingService.NewRatingService(environment, loggr, validatr, db)
+	idempotency := idempotencyService.NewIdempotencyService(environment, loggr, validatr, idempotencyKeys)
+	rating.NewRatingController(environment, loggr, validatr, service, idempotency, ratelimit.NewMemoryStore(environment.GetInt(env.RateLimitMaxBuckets, 100000))).RegisterRoutes(v1)
 }
 
 func addSwagger(router *gin.Engine, environment env.IEnvironment) {
@@ -58,3 +153,66 @@ func addSwagger(router *gin.Engine, environment env.IEnvironment) {
 	docs.SwaggerInfo.Host = environment.Get(env.AppHost)
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
+
+// trustedProxies
+// Returns the proxies of APP_TRUSTED_PROXIES, or nil so that ClientIP is the remote address and
+// a client cannot pick its own address with an X-Forwarded-For header.
+func trustedProxies(environment env.IEnvironment) []string {
+	var proxies []string
+	for _, proxy := range strings.Split(environment.Get(env.AppTrustedProxies), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	return proxies
+}
+
+// listenAddress
+// Returns the address of the HTTP server from PORT like gin's router.Run, :8080 when it is not set.
+func listenAddress(environment env.IEnvironment) string {
+	if port := environment.Get(env.AppPort); port != "" {
+		return ":" + port
+	}
+
+	return ":8080"
+}
+
+// serve
+// Runs the HTTP server until SIGINT or SIGTERM, then drains in-flight requests
+// so deferred cleanup (e.g. closing the database pool) runs on shutdown.
+// Returns the error when the server cannot listen, stops on its own or does not drain in time.
+func serve(router *gin.Engine, environment env.IEnvironment, loggr logger.ILogger) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{
+		Addr:    listenAddress(environment),
+		Handler: router,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		// only Shutdown makes ListenAndServe return http.ErrServerClosed, so this is a failed bind or a crash
+		loggr.Error("HTTP server stopped unexpectedly", zap.Error(err))
+		return err
+	case <-ctx.Done():
+	}
+
+	loggr.Info("Shutting down HTTP server")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		loggr.Error("HTTP server shutdown failed", zap.Error(err))
+		return err
+	}
+
+	return nil
+}